@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// exportAndroid writes values[-<lang>]/<name>.xml string resources; the source language goes to the
+// default values directory. Messages with forms become <plurals>.
+func exportAndroid(cfg *exportConfig, source *langFile, targets []*langFile) error {
+	byLang, skipped := mobileMessages(source, targets, androidName)
+	for _, file := range append([]*langFile{source}, targets...) {
+		dir := "values"
+		if file != source {
+			dir = androidValuesDir(file.lang)
+		}
+		if err := cfg.writeExport(filepath.Join(dir, cfg.name+".xml"), androidResources(source, byLang[file.lang])); err != nil {
+			return err
+		}
+	}
+	reportMobileSkips("android", skipped)
+	return nil
+}
+
+// androidName turns a key into a resource name: lowercase letters, digits and underscores, starting
+// with a letter (e.g. "greeting.hello" -> "greeting_hello", long text "greeting_hello_long").
+func androidName(key string, long bool) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(key) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	name := b.String()
+	if name[0] < 'a' || name[0] > 'z' {
+		name = "msg_" + name
+	}
+	if long {
+		name += "_long"
+	}
+	return name
+}
+
+// androidValuesDir returns the resource directory for a language (e.g. "pt-br" -> "values-pt-rBR",
+// "zh-hant" -> "values-b+zh+Hant").
+func androidValuesDir(lang string) string {
+	language, script, region := localeParts(lang)
+	if script != "" {
+		return "values-b+" + joinLocale("+", language, script, region)
+	}
+	if region != "" {
+		return "values-" + language + "-r" + region
+	}
+	return "values-" + language
+}
+
+func androidResources(source *langFile, messages []*mobileMessage) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	fmt.Fprintf(&buf, "<!-- Generated by msgcat export from %s. -->\n", xmlComment(filepath.Base(source.path)))
+	buf.WriteString("<resources>\n")
+	for _, msg := range messages {
+		fmt.Fprintf(&buf, "    <!-- %s -->\n", xmlComment(mobileComment(msg)))
+		if msg.forms == nil {
+			fmt.Fprintf(&buf, "    <string name=\"%s\">%s</string>\n", msg.name, androidText(msg, msg.parts))
+			continue
+		}
+		fmt.Fprintf(&buf, "    <plurals name=\"%s\">\n", msg.name)
+		for _, form := range msg.formNames() {
+			fmt.Fprintf(&buf, "        <item quantity=\"%s\">%s</item>\n", form, androidText(msg, msg.forms[form]))
+		}
+		buf.WriteString("    </plurals>\n")
+	}
+	buf.WriteString("</resources>\n")
+	return buf.Bytes()
+}
+
+// mobileComment describes a message for translators and developers: the catalog key, its arguments
+// in order, and the entry comment.
+func mobileComment(msg *mobileMessage) string {
+	comment := msg.key
+	if len(msg.params) > 0 {
+		names := make([]string, len(msg.params))
+		for i, param := range msg.params {
+			names[i] = fmt.Sprintf("%d=%s", i+1, param.name)
+		}
+		comment += " (args: " + strings.Join(names, ", ") + ")"
+	}
+	if msg.comment != "" {
+		comment += ": " + strings.ReplaceAll(msg.comment, "\n", " ")
+	}
+	return comment
+}
+
+// androidText renders parts as a resource string: %n$s arguments (%n$d for the plural count) and
+// Android escaping. '%' is doubled only when the string takes arguments.
+func androidText(msg *mobileMessage, parts []mobilePart) string {
+	formatted := len(msg.params) > 0
+	text := renderParts(parts, func(s string) string {
+		var b strings.Builder
+		for _, r := range s {
+			switch r {
+			case '&':
+				b.WriteString("&amp;")
+			case '<':
+				b.WriteString("&lt;")
+			case '>':
+				b.WriteString("&gt;")
+			case '\\':
+				b.WriteString(`\\`)
+			case '\'':
+				b.WriteString(`\'`)
+			case '"':
+				b.WriteString(`\"`)
+			case '\n':
+				b.WriteString(`\n`)
+			case '\t':
+				b.WriteString(`\t`)
+			case '%':
+				if formatted {
+					b.WriteString("%%")
+				} else {
+					b.WriteByte('%')
+				}
+			default:
+				b.WriteRune(r)
+			}
+		}
+		return b.String()
+	}, func(index int) string {
+		if msg.params[index-1].kind == paramCount {
+			return fmt.Sprintf("%%%d$d", index)
+		}
+		return fmt.Sprintf("%%%d$s", index)
+	})
+	if strings.HasPrefix(text, "@") || strings.HasPrefix(text, "?") {
+		text = `\` + text
+	}
+	return text
+}
+
+// xmlComment makes s safe inside <!-- -->.
+func xmlComment(s string) string {
+	return strings.ReplaceAll(s, "--", "- -")
+}
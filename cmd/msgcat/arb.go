@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// exportARB writes <name>_<locale>.arb files for Flutter gen-l10n. Placeholders become {name}, forms
+// become ICU plurals, and the source file (the template) carries the @key metadata.
+func exportARB(cfg *exportConfig, source *langFile, targets []*langFile) error {
+	byLang, skipped := mobileMessages(source, targets, arbName)
+	for _, file := range append([]*langFile{source}, targets...) {
+		var messages []*mobileMessage
+		for _, msg := range byLang[file.lang] {
+			if err := arbCheck(msg); err != nil {
+				skipped = append(skipped, mobileSkip{file.lang, textLabel(msg.key, msg.long), err.Error()})
+				continue
+			}
+			messages = append(messages, msg)
+		}
+		data, err := arbFile(file.lang, messages, file == source)
+		if err != nil {
+			return err
+		}
+		if err := cfg.writeExport(cfg.name+"_"+arbLocale(file.lang)+".arb", data); err != nil {
+			return err
+		}
+	}
+	reportMobileSkips("arb", skipped)
+	return nil
+}
+
+// arbName turns a key into a lowerCamelCase Dart identifier (e.g. "greeting.hello" -> "greetingHello",
+// long text "greetingHelloLong").
+func arbName(key string, long bool) string {
+	words := strings.FieldsFunc(key, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	if long {
+		words = append(words, "long")
+	}
+	var b strings.Builder
+	for i, word := range words {
+		if i == 0 {
+			b.WriteString(strings.ToLower(word[:1]) + word[1:])
+		} else {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "msg" + name
+	}
+	return name
+}
+
+// arbLocale returns the ARB locale for a language (e.g. "pt-br" -> "pt_BR").
+func arbLocale(lang string) string {
+	language, script, region := localeParts(lang)
+	return joinLocale("_", language, script, region)
+}
+
+// arbParamName turns a param into a Dart identifier ("user.name" -> "user_name").
+func arbParamName(name string) string {
+	return strings.ReplaceAll(name, ".", "_")
+}
+
+// arbCheck reports literal text that ICU would read as syntax.
+func arbCheck(msg *mobileMessage) error {
+	parts := msg.parts
+	for _, form := range msg.formNames() {
+		parts = append(parts, msg.forms[form]...)
+	}
+	for _, part := range parts {
+		if part.param == 0 && strings.ContainsAny(part.text, "{}") {
+			return fmt.Errorf("literal braces are not supported in ARB messages")
+		}
+	}
+	return nil
+}
+
+// arbText renders a message as an ICU message: {name} placeholders, and a plural select over the
+// plural param for messages with forms.
+func arbText(msg *mobileMessage) string {
+	placeholder := func(index int) string { return "{" + arbParamName(msg.params[index-1].name) + "}" }
+	identity := func(s string) string { return s }
+	if msg.forms == nil {
+		return renderParts(msg.parts, identity, placeholder)
+	}
+	var b strings.Builder
+	b.WriteString("{" + arbParamName(msg.params[0].name) + ", plural,")
+	for _, form := range msg.formNames() {
+		b.WriteString(" " + form + "{" + renderParts(msg.forms[form], identity, placeholder) + "}")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// arbPlaceholder is the metadata of one placeholder.
+func arbPlaceholder(param mobileParam) map[string]string {
+	switch param.kind {
+	case paramCount:
+		return map[string]string{"type": "int"}
+	case paramNum:
+		return map[string]string{"type": "num", "format": "decimalPattern"}
+	case paramDate:
+		return map[string]string{"type": "DateTime", "format": "yMd"}
+	}
+	return map[string]string{"type": "String"}
+}
+
+// arbFile renders the messages of one language in order. withMetadata adds @key entries with the
+// description (entry comment or catalog key) and placeholders.
+func arbFile(lang string, messages []*mobileMessage, withMetadata bool) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	first := true
+	writeField := func(name string, value interface{}) error {
+		var encoded bytes.Buffer
+		enc := json.NewEncoder(&encoded)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("  ", "  ")
+		if err := enc.Encode(value); err != nil {
+			return err
+		}
+		if !first {
+			buf.WriteString(",\n")
+		}
+		first = false
+		fmt.Fprintf(&buf, "  %q: %s", name, bytes.TrimSuffix(encoded.Bytes(), []byte("\n")))
+		return nil
+	}
+	if err := writeField("@@locale", arbLocale(lang)); err != nil {
+		return nil, err
+	}
+	for _, msg := range messages {
+		if err := writeField(msg.name, arbText(msg)); err != nil {
+			return nil, err
+		}
+		if !withMetadata {
+			continue
+		}
+		description := msg.comment
+		if description == "" {
+			description = msg.key
+		}
+		meta := arbMetadata{Description: description}
+		if len(msg.params) > 0 {
+			meta.Placeholders = map[string]map[string]string{}
+			for _, param := range msg.params {
+				meta.Placeholders[arbParamName(param.name)] = arbPlaceholder(param)
+			}
+		}
+		if err := writeField("@"+msg.name, meta); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteString("\n}\n")
+	return buf.Bytes(), nil
+}
+
+// arbMetadata is the @key object of a message.
+type arbMetadata struct {
+	Description  string                       `json:"description"`
+	Placeholders map[string]map[string]string `json:"placeholders,omitempty"`
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// readKeyComments returns the comment written above each set entry (flat or nested file), keyed by
+// message key, with the leading "# " removed from every line.
+func readKeyComments(data []byte) (map[string]string, error) {
+	set, err := setNode(data, nil)
+	if err != nil || set == nil {
+		return nil, err
+	}
+	comments := make(map[string]string)
+	walkSetEntries(set, "", func(key string, keyNode *yamlv3.Node) {
+		if keyNode.HeadComment == "" {
+			return
+		}
+		lines := strings.Split(keyNode.HeadComment, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimPrefix(strings.TrimPrefix(line, "#"), " ")
+		}
+		comments[key] = strings.Join(lines, "\n")
+	})
+	return comments, nil
+}
+
+// withKeyComments rewrites YAML data with comments placed above the matching set entries. Data is
+// returned unchanged when there are no comments.
+func withKeyComments(data []byte, comments map[string]string) ([]byte, error) {
+	if len(comments) == 0 {
+		return data, nil
+	}
+	var doc yamlv3.Node
+	set, err := setNode(data, &doc)
+	if err != nil || set == nil {
+		return data, err
+	}
+	walkSetEntries(set, "", func(key string, keyNode *yamlv3.Node) {
+		if comment, ok := comments[key]; ok {
+			keyNode.HeadComment = comment
+		}
+	})
+	var buf bytes.Buffer
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// setNode parses data (into doc when not nil) and returns the mapping node under "set", or nil.
+func setNode(data []byte, doc *yamlv3.Node) (*yamlv3.Node, error) {
+	if doc == nil {
+		doc = &yamlv3.Node{}
+	}
+	if err := yamlv3.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yamlv3.MappingNode {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "set" && root.Content[i+1].Kind == yamlv3.MappingNode {
+			return root.Content[i+1], nil
+		}
+	}
+	return nil, nil
+}
+
+// walkSetEntries calls fn with the message key and key node of every entry, descending into nested
+// levels like msgcat.UnmarshalNestedMessages. Any entry field marks an entry, so flat entries with
+// only code are not taken for a level of nesting.
+func walkSetEntries(node *yamlv3.Node, prefix string, fn func(key string, keyNode *yamlv3.Node)) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, value := node.Content[i], node.Content[i+1]
+		key := prefix + keyNode.Value
+		if value.Kind == yamlv3.MappingNode && !isEntryNode(value) {
+			walkSetEntries(value, key+".", fn)
+			continue
+		}
+		fn(key, keyNode)
+	}
+}
+
+func isEntryNode(node *yamlv3.Node) bool {
+	if len(node.Content) == 0 {
+		return true
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		switch node.Content[i].Value {
+		case "short", "long", "short_forms", "long_forms", "code", "status", "plural_param", "state",
+			"description", "context", "max_length":
+			return true
+		}
+	}
+	return false
+}
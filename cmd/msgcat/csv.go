@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/loopcontext/msgcat/internal/plural"
+)
+
+// csvBOM is written before the header so spreadsheet apps open the file as UTF-8; import strips it.
+const csvBOM = "\ufeff"
+
+// csvColumn is one language column: field is short, long, short_forms.<form> or long_forms.<form>.
+type csvColumn struct {
+	lang  string
+	field string
+}
+
+func (c csvColumn) String() string { return c.lang + "." + c.field }
+
+func exportCSV(cfg *exportConfig, source *langFile, targets []*langFile) error {
+	files := append([]*langFile{source}, targets...)
+	var hasShortForms, hasLongForms bool
+	for _, entry := range source.messages.Set {
+		hasShortForms = hasShortForms || len(entry.ShortForms) > 0
+		hasLongForms = hasLongForms || len(entry.LongForms) > 0
+	}
+	header := []string{"key", "code", "group"}
+	var columns []csvColumn
+	for _, file := range files {
+		columns = append(columns, csvColumn{file.lang, "short"}, csvColumn{file.lang, "long"})
+		if hasShortForms {
+			for _, form := range plural.Forms(file.lang) {
+				columns = append(columns, csvColumn{file.lang, "short_forms." + form})
+			}
+		}
+		if hasLongForms {
+			for _, form := range plural.Forms(file.lang) {
+				columns = append(columns, csvColumn{file.lang, "long_forms." + form})
+			}
+		}
+	}
+	for _, column := range columns {
+		header = append(header, column.String())
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(csvBOM)
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	byLang := make(map[string]*langFile, len(files))
+	for _, file := range files {
+		byLang[file.lang] = file
+	}
+	for _, key := range source.sortedKeys() {
+		row := []string{key, string(source.messages.Set[key].Code), string(source.messages.Group)}
+		for _, column := range columns {
+			entry := byLang[column.lang].messages.Set[key]
+			switch {
+			case column.field == "short":
+				row = append(row, entry.ShortTpl)
+			case column.field == "long":
+				row = append(row, entry.LongTpl)
+			case strings.HasPrefix(column.field, "short_forms."):
+				row = append(row, entry.ShortForms[strings.TrimPrefix(column.field, "short_forms.")])
+			default:
+				row = append(row, entry.LongForms[strings.TrimPrefix(column.field, "long_forms.")])
+			}
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return cfg.writeExport(cfg.name+".csv", buf.Bytes())
+}
+
+// parseCSVColumn parses a "<lang>.<field>" header cell.
+func parseCSVColumn(name string) (csvColumn, error) {
+	lang, field, ok := strings.Cut(strings.TrimSpace(name), ".")
+	column := csvColumn{lang: strings.ToLower(lang), field: field}
+	if !ok || column.lang == "" {
+		return column, fmt.Errorf("unknown column %q (want key, code, group or <lang>.<field>)", name)
+	}
+	switch {
+	case field == "short", field == "long":
+	case strings.HasPrefix(field, "short_forms.") && len(field) > len("short_forms."):
+	case strings.HasPrefix(field, "long_forms.") && len(field) > len("long_forms."):
+	default:
+		return column, fmt.Errorf("unknown field in column %q (want short, long, short_forms.<form> or long_forms.<form>)", name)
+	}
+	return column, nil
+}
+
+func importCSV(cfg *importConfig, path string) (map[string]map[string]importUpdate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte(csvBOM)))).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty file")
+	}
+	keyIndex := -1
+	columns := map[int]csvColumn{}
+	for i, name := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "key":
+			keyIndex = i
+		case "code", "group":
+			// Informational; code and group are taken from the source file.
+		default:
+			column, err := parseCSVColumn(name)
+			if err != nil {
+				return nil, err
+			}
+			columns[i] = column
+		}
+	}
+	if keyIndex < 0 {
+		return nil, fmt.Errorf("missing key column")
+	}
+	updates := map[string]map[string]importUpdate{}
+	for _, record := range records[1:] {
+		key := strings.TrimSpace(record[keyIndex])
+		if key == "" {
+			continue
+		}
+		for i, column := range columns {
+			text := record[i]
+			if text == "" {
+				continue
+			}
+			if updates[column.lang] == nil {
+				updates[column.lang] = map[string]importUpdate{}
+			}
+			update := updates[column.lang][key]
+			switch {
+			case column.field == "short":
+				update.short = text
+			case column.field == "long":
+				update.long = text
+			case strings.HasPrefix(column.field, "short_forms."):
+				update.shortForms = mergeForms(update.shortForms, map[string]string{strings.TrimPrefix(column.field, "short_forms."): text})
+			default:
+				update.longForms = mergeForms(update.longForms, map[string]string{strings.TrimPrefix(column.field, "long_forms."): text})
+			}
+			updates[column.lang][key] = update
+		}
+	}
+	return updates, nil
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const csvTestSource = `group: api
+default:
+  short: Err
+  long: Err
+set:
+  greeting.hello:
+    code: GREETING
+    short: Hello, "{{name}}"
+    long: "Line1\nLine2"
+  person.cats:
+    short_forms:
+      one: "{{count}} cat"
+      other: "{{count}} cats"
+`
+
+func TestExportCSV(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "en.yaml"), csvTestSource)
+	writeTestFile(t, filepath.Join(dir, "ru.yaml"), `default:
+  short: Ошибка
+  long: Ошибка
+set:
+  greeting.hello:
+    short: Привет
+`)
+	if err := runExport(&exportConfig{format: "csv", source: filepath.Join(dir, "en.yaml"), targetLangs: "ru", name: "messages"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "messages.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte(csvBOM)) {
+		t.Error("csv should start with a UTF-8 BOM")
+	}
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte(csvBOM)))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"key", "code", "group", "en.short", "en.long", "en.short_forms.one", "en.short_forms.other",
+			"ru.short", "ru.long", "ru.short_forms.one", "ru.short_forms.few", "ru.short_forms.many"},
+		{"greeting.hello", "GREETING", "api", `Hello, "{{name}}"`, "Line1\nLine2", "", "", "Привет", "", "", "", ""},
+		{"person.cats", "", "api", "", "", "{{count}} cat", "{{count}} cats", "", "", "", "", ""},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %q\nwant %q", records, want)
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "en.yaml"), csvTestSource)
+	ru := `default:
+  short: Ошибка
+  long: Ошибка
+set:
+  greeting.hello:
+    short: Привет
+`
+	writeTestFile(t, filepath.Join(dir, "ru.yaml"), ru)
+	writeTestFile(t, filepath.Join(dir, "edits.csv"), csvBOM+`key,code,group,en.short,ru.short,ru.short_forms.few,es.short
+greeting.hello,GREETING,api,"Hello, ""{{name}}""",Здравствуй,,"Hola, ""{{name}}"""
+person.cats,,api,,,{{count}} кошки,
+unknown.key,,,,x,,
+`)
+	cfg := &importConfig{format: "csv", source: filepath.Join(dir, "en.yaml"), dryRun: true, inputs: []string{filepath.Join(dir, "edits.csv")}}
+	if err := runImport(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "ru.yaml")); string(got) != ru {
+		t.Errorf("dry run modified ru.yaml:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "es.yaml")); !os.IsNotExist(err) {
+		t.Errorf("dry run created es.yaml (err=%v)", err)
+	}
+
+	cfg.dryRun = false
+	if err := runImport(cfg); err != nil {
+		t.Fatal(err)
+	}
+	ruFile, err := readLangFile("ru", filepath.Join(dir, "ru.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ruFile.messages.Set["greeting.hello"].ShortTpl; got != "Здравствуй" {
+		t.Errorf("ru greeting.hello short = %q", got)
+	}
+	if got := ruFile.messages.Set["person.cats"].ShortForms; !reflect.DeepEqual(got, map[string]string{"few": "{{count}} кошки"}) {
+		t.Errorf("ru person.cats short_forms = %v", got)
+	}
+	if _, ok := ruFile.messages.Set["unknown.key"]; ok {
+		t.Error("keys missing from the source should be skipped")
+	}
+	esFile, err := readLangFile("es", filepath.Join(dir, "es.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry := esFile.messages.Set["greeting.hello"]; entry.ShortTpl != `Hola, "{{name}}"` || entry.Code != "GREETING" {
+		t.Errorf("es greeting.hello = %+v", entry)
+	}
+	if esFile.messages.Group != "api" {
+		t.Errorf("es group = %q, want api", esFile.messages.Group)
+	}
+	if entry, ok := esFile.messages.Set["person.cats"]; ok {
+		t.Errorf("untranslated es person.cats was written: %+v", entry)
+	}
+}
+
+func TestImportCSV_untranslatedMatchesDryRun(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "en.yaml"), csvTestSource)
+	writeTestFile(t, filepath.Join(dir, "edits.csv"), `key,es.short,es.long,es.short_forms.one,es.short_forms.other
+greeting.hello,Hola,,,
+person.cats,,,,
+`)
+	cfg := &importConfig{format: "csv", source: filepath.Join(dir, "en.yaml"), targetDir: dir}
+	source, err := readLangFile("en", cfg.source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updates, err := importCSV(cfg, filepath.Join(dir, "edits.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := readImportTarget(cfg, source, "es")
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := applyImport(source, target, updates["es"])
+	var diff bytes.Buffer
+	printImportDiff(&diff, target.path, changes)
+	if want := target.path + ": 1 key(s) would change\n  greeting.hello\n    short: \"\" -> \"Hola\"\n"; diff.String() != want {
+		t.Errorf("diff =\n%s\nwant\n%s", diff.String(), want)
+	}
+
+	cfg.inputs = []string{filepath.Join(dir, "edits.csv")}
+	if err := runImport(cfg); err != nil {
+		t.Fatal(err)
+	}
+	es, err := readLangFile("es", filepath.Join(dir, "es.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := es.sortedKeys(); !reflect.DeepEqual(keys, []string{"greeting.hello"}) {
+		t.Errorf("es.yaml keys = %v, want only the keys listed by the dry run", keys)
+	}
+}
+
+func TestImportCSV_unknownColumn(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "bad.csv"), "key,es.title\n")
+	_, err := importCSV(&importConfig{}, filepath.Join(dir, "bad.csv"))
+	if err == nil || !strings.Contains(err.Error(), `"es.title"`) {
+		t.Errorf("err = %v, want unknown field error", err)
+	}
+}
+
+func TestPrintImportDiff(t *testing.T) {
+	var buf bytes.Buffer
+	printImportDiff(&buf, "es.yaml", []importChange{
+		{key: "a", field: "short", old: "", new: "Hola"},
+		{key: "a", field: "long", old: "Viejo", new: "Nuevo"},
+		{key: "b", field: "short_forms.one", old: "", new: "uno"},
+	})
+	want := `es.yaml: 2 key(s) would change
+  a
+    short: "" -> "Hola"
+    long: "Viejo" -> "Nuevo"
+  b
+    short_forms.one: "" -> "uno"
+`
+	if buf.String() != want {
+		t.Errorf("diff =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
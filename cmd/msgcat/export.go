@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/loopcontext/msgcat"
+)
+
+// exportConfig holds flags for the export command.
+type exportConfig struct {
+	format       string
+	source       string
+	sourceLang   string
+	targetLangs  string
+	targetDir    string
+	outdir       string
+	name         string
+	xliffVersion string
+	group        string
+}
+
+// exportFormats maps -format values to exporters. source is the source language file; targets are
+// the other languages (files that do not exist yet have an empty set).
+var exportFormats = map[string]func(cfg *exportConfig, source *langFile, targets []*langFile) error{
+	"android": exportAndroid,
+	"arb":     exportARB,
+	"csv":     exportCSV,
+	"ios":     exportIOS,
+	"js":      exportJS,
+	"po":      exportPO,
+	"xliff":   exportXLIFF,
+}
+
+// exportDefaultNames is the default -name per format; other formats use "messages".
+var exportDefaultNames = map[string]string{
+	"android": "strings",
+	"arb":     "app",
+	"ios":     "Localizable",
+}
+
+func usageExport() {
+	fmt.Fprint(os.Stderr, `usage: msgcat export -format <format> [options]
+
+Export converts the source message file and its translations into a translation exchange format.
+Target languages come from -targetLangs, or from the *.yaml files in -targetDir (default: the
+source directory, excluding the source and translate.* files).
+
+Formats:
+  android  values[-<lang>]/<name>.xml string resources (source language in values/); forms and
+           {{plural:...}} tokens become <plurals>, placeholders %1$s (%1$d for the plural count).
+  arb      <name>_<locale>.arb for Flutter gen-l10n; placeholders {name}, forms as ICU plurals, and
+           @key metadata in the source language file.
+  csv      <name>.csv with one row per source key: key, code, group, then <lang>.short, <lang>.long and
+           <lang>.short_forms.<form> / <lang>.long_forms.<form> columns for the source and each target.
+  ios      <lang>.lproj/<name>.strings and <name>.stringsdict (forms); placeholders %1$@ (%1$ld for
+           the plural count).
+  js       <name>.<lang>.json bundles (every source key; untranslated entries use the source) and
+           <name>.d.ts with the keys and their params. Reads all files of each language
+           (<lang>.yaml, <lang>.<name>.yaml, <lang>/) and keeps those in -group when set.
+  po       <name>.pot template from the source plus <lang>.po per target language (gettext).
+  xliff    <name>.<lang>.xlf per target language (XLIFF 1.2 or 2.0, see -xliffVersion); one unit per
+           key with short/long as segments, {{name}} placeholders as <ph>, and translation state.
+
+Flags:
+`)
+	flag.CommandLine.PrintDefaults()
+}
+
+func parseExportFlags(args []string) (*exportConfig, error) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	fs.Usage = usageExport
+	var cfg exportConfig
+	fs.StringVar(&cfg.format, "format", "", "Output format: "+strings.Join(formatNames(exportFormats), ", ")+". Required.")
+	fs.StringVar(&cfg.source, "source", "", "Source message file (e.g. resources/messages/en.yaml). Required.")
+	fs.StringVar(&cfg.sourceLang, "sourceLang", "", "Source language (default: inferred from the source file name).")
+	fs.StringVar(&cfg.targetLangs, "targetLangs", "", "Comma-separated target language tags (e.g. es,fr).")
+	fs.StringVar(&cfg.targetDir, "targetDir", "", "Directory containing target <lang>.yaml files (default: same dir as source).")
+	fs.StringVar(&cfg.outdir, "outdir", "", "Where to write exported files (default: same dir as source).")
+	fs.StringVar(&cfg.name, "name", "", "Base name for exported files (default: strings for android, Localizable for ios, app for arb, messages otherwise).")
+	fs.StringVar(&cfg.xliffVersion, "xliffVersion", "1.2", "XLIFF version for -format xliff: 1.2 or 2.0.")
+	fs.StringVar(&cfg.group, "group", "", "For -format js: only export files whose group matches (e.g. web).")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func runExport(cfg *exportConfig) error {
+	exporter, ok := exportFormats[cfg.format]
+	if !ok {
+		return fmt.Errorf("export: unknown -format %q (want %s)", cfg.format, strings.Join(formatNames(exportFormats), ", "))
+	}
+	if cfg.source == "" {
+		return fmt.Errorf("export: -source is required")
+	}
+	if cfg.name == "" {
+		cfg.name = exportDefaultNames[cfg.format]
+		if cfg.name == "" {
+			cfg.name = "messages"
+		}
+	}
+	if cfg.sourceLang == "" {
+		cfg.sourceLang = langFromPath(cfg.source)
+	}
+	source, err := readLangFile(cfg.sourceLang, cfg.source)
+	if err != nil {
+		return fmt.Errorf("read source: %w", err)
+	}
+	targets, err := readTargetFiles(source, cfg.targetLangs, cfg.targetDir)
+	if err != nil {
+		return err
+	}
+	if cfg.outdir == "" {
+		cfg.outdir = filepath.Dir(cfg.source)
+	}
+	if err := os.MkdirAll(cfg.outdir, 0755); err != nil {
+		return err
+	}
+	return exporter(cfg, source, targets)
+}
+
+// readTargetFiles reads <lang>.yaml from targetDir (default: the source directory) for each target
+// language: the comma-separated targetLangs, or the languages found in targetDir. Files that do not
+// exist yet have an empty set.
+func readTargetFiles(source *langFile, targetLangs, targetDir string) ([]*langFile, error) {
+	if targetDir == "" {
+		targetDir = filepath.Dir(source.path)
+	}
+	langs := splitLangs(targetLangs)
+	if len(langs) == 0 {
+		var err error
+		if langs, err = readTargetLangsFromDir(targetDir, source.path); err != nil {
+			return nil, err
+		}
+	}
+	var targets []*langFile
+	for _, lang := range langs {
+		if lang == source.lang {
+			continue
+		}
+		target, err := readLangFile(lang, filepath.Join(targetDir, lang+".yaml"))
+		if errors.Is(err, os.ErrNotExist) {
+			target = &langFile{lang: lang, path: filepath.Join(targetDir, lang+".yaml"),
+				messages: msgcat.Messages{Set: map[string]msgcat.RawMessage{}}, comments: map[string]string{}}
+		} else if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// writeExport writes one exported file into the output directory and reports it.
+func (c *exportConfig) writeExport(name string, data []byte) error {
+	outPath := filepath.Join(c.outdir, name)
+	if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(outPath, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", outPath, err)
+	}
+	fmt.Fprintf(os.Stderr, "msgcat: wrote %s\n", outPath)
+	return nil
+}
+
+// formatNames returns the sorted keys of a format registry, for usage and errors.
+func formatNames[T any](formats map[string]T) []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
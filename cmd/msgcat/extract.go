@@ -29,7 +29,7 @@ func usageExtract() {
 	fmt.Fprintf(os.Stderr, `usage: msgcat extract [options] [paths]
 
 Extract discovers message keys referenced in Go code (GetMessageWithCtx, WrapErrorWithCtx,
-GetErrorWithCtx) and optionally syncs them into a source language YAML file.
+GetErrorWithCtx, msgcat.NewError) and optionally syncs them into a source language YAML file.
 
 If no paths are provided, scans the current directory.
 
@@ -37,6 +37,10 @@ Modes:
   - Keys only: omit -source; writes unique keys (one per line) to -out or stdout.
   - Sync to YAML: set -source to a msgcat YAML file; adds missing keys with empty short/long, writes to -out.
 
+A "// msgcat: <note>" comment directly above a call or MessageDef literal is written as a YAML
+comment above the entry on sync, with the file:line of the call (e.g. "# msgcat: shown when the
+cart is empty (cart/view.go:42)").
+
 Flags:
 `)
 	flag.CommandLine.PrintDefaults()
@@ -68,7 +72,10 @@ type keyExtractor struct {
 	msgcatName   string // local name in current file (e.g. "msgcat")
 	keys         map[string]struct{}
 	defs         map[string]msgcat.RawMessage // key -> content from MessageDef literals
+	notes        map[string][]string          // key -> "// msgcat:" comments with file:line (see extract_comments.go)
+	file         *sourceFile                  // file being walked
 	methodArgIdx map[string]int
+	funcArgIdx   map[string]int // package-level msgcat functions taking a key
 }
 
 func newKeyExtractor(msgcatImport string) *keyExtractor {
@@ -76,11 +83,15 @@ func newKeyExtractor(msgcatImport string) *keyExtractor {
 		msgcatImport: msgcatImport,
 		keys:         make(map[string]struct{}),
 		defs:         make(map[string]msgcat.RawMessage),
+		notes:        make(map[string][]string),
 		methodArgIdx: map[string]int{
 			"GetMessageWithCtx":  1,
 			"GetErrorWithCtx":   1,
 			"WrapErrorWithCtx":  2,
 		},
+		funcArgIdx: map[string]int{
+			"NewError": 0,
+		},
 	}
 }
 
@@ -94,6 +105,7 @@ func (e *keyExtractor) extractFromFile(path string, src []byte) error {
 	if e.msgcatName == "" {
 		return nil
 	}
+	e.file = newSourceFile(path, src, fset, f)
 	ast.Walk(e, f)
 	return nil
 }
@@ -121,7 +133,7 @@ func (e *keyExtractor) Visit(node ast.Node) ast.Visitor {
 		e.visitCompositeLit(cl)
 		return e
 	}
-	// API calls: GetMessageWithCtx, WrapErrorWithCtx, GetErrorWithCtx
+	// API calls: GetMessageWithCtx, WrapErrorWithCtx, GetErrorWithCtx, msgcat.NewError
 	call, ok := node.(*ast.CallExpr)
 	if !ok {
 		return e
@@ -132,7 +144,11 @@ func (e *keyExtractor) Visit(node ast.Node) ast.Visitor {
 	}
 	idx, ok := e.methodArgIdx[sel.Sel.Name]
 	if !ok {
-		return e
+		idx, ok = e.funcArgIdx[sel.Sel.Name]
+		// Package-level functions must be called on the msgcat import (msgcat.NewError).
+		if id, isIdent := sel.X.(*ast.Ident); !ok || !isIdent || id.Name != e.msgcatName {
+			return e
+		}
 	}
 	if idx >= len(call.Args) {
 		return e
@@ -140,6 +156,7 @@ func (e *keyExtractor) Visit(node ast.Node) ast.Visitor {
 	key := e.extractString(call.Args[idx])
 	if key != "" {
 		e.keys[key] = struct{}{}
+		e.addNotes(key, call)
 	}
 	return e
 }
@@ -152,6 +169,7 @@ func (e *keyExtractor) visitCompositeLit(cl *ast.CompositeLit) {
 			if key != "" {
 				e.defs[key] = raw
 				e.keys[key] = struct{}{}
+				e.addNotes(key, cl)
 			}
 		}
 	case *ast.ArrayType:
@@ -162,6 +180,7 @@ func (e *keyExtractor) visitCompositeLit(cl *ast.CompositeLit) {
 					if key != "" {
 						e.defs[key] = raw
 						e.keys[key] = struct{}{}
+						e.addNotes(key, inner)
 					}
 				}
 			}
@@ -181,6 +200,7 @@ func (e *keyExtractor) visitCompositeLit(cl *ast.CompositeLit) {
 				if key != "" {
 					e.defs[key] = raw
 					e.keys[key] = struct{}{}
+					e.addNotes(key, kve)
 				}
 			}
 		}
@@ -289,7 +309,7 @@ func runExtract(cfg *extractConfig) error {
 	}
 	keys := ext.sortedKeys()
 	if cfg.source != "" {
-		return runExtractSync(cfg, keys, ext.defs)
+		return runExtractSync(cfg, keys, ext.defs, ext.notes)
 	}
 	// Keys-only output (one per line)
 	out := strings.Join(keys, "\n")
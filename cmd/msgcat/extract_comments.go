@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// notePrefix marks translator comments in Go source ("// msgcat: shown when the cart is empty") and
+// the YAML comment lines extract writes from them.
+const notePrefix = "msgcat:"
+
+// sourceFile is a parsed Go file with its comment groups indexed by the line they end on.
+type sourceFile struct {
+	path     string
+	src      []byte
+	fset     *token.FileSet
+	groupEnd map[int]*ast.CommentGroup
+}
+
+func newSourceFile(path string, src []byte, fset *token.FileSet, f *ast.File) *sourceFile {
+	file := &sourceFile{path: path, src: src, fset: fset, groupEnd: make(map[int]*ast.CommentGroup)}
+	for _, group := range f.Comments {
+		file.groupEnd[fset.Position(group.End()).Line] = group
+	}
+	return file
+}
+
+// addNotes records the msgcat comments directly above node for key, with the file:line of node.
+func (e *keyExtractor) addNotes(key string, node ast.Node) {
+	if e.file == nil {
+		return
+	}
+	pos := e.file.fset.Position(node.Pos())
+	group, ok := e.file.groupEnd[pos.Line-1]
+	if !ok || !e.file.ownLine(group) {
+		return
+	}
+	ref := sourceRef(e.file.path, pos.Line)
+	for _, text := range commentNotes(group) {
+		note := text + " (" + ref + ")"
+		if !containsString(e.notes[key], note) {
+			e.notes[key] = append(e.notes[key], note)
+		}
+	}
+}
+
+// ownLine reports whether group starts on a line of its own, so a trailing comment of the previous
+// line is not taken for a comment above the next one.
+func (f *sourceFile) ownLine(group *ast.CommentGroup) bool {
+	pos := f.fset.Position(group.Pos())
+	return len(bytes.TrimSpace(f.src[pos.Offset-pos.Column+1:pos.Offset])) == 0
+}
+
+// commentNotes returns the notes of a comment group. A note starts at a "msgcat:" line and continues
+// over the following lines up to an empty line or the next "msgcat:" line; its lines are joined with
+// spaces.
+func commentNotes(group *ast.CommentGroup) []string {
+	var lines []string
+	for _, c := range group.List {
+		if strings.HasPrefix(c.Text, "//") {
+			lines = append(lines, c.Text[2:])
+			continue
+		}
+		lines = append(lines, strings.Split(strings.TrimSuffix(strings.TrimPrefix(c.Text, "/*"), "*/"), "\n")...)
+	}
+	var notes []string
+	inNote := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, notePrefix):
+			notes = append(notes, strings.TrimSpace(line[len(notePrefix):]))
+			inNote = true
+		case line == "":
+			inNote = false
+		case inNote:
+			notes[len(notes)-1] = strings.TrimSpace(notes[len(notes)-1] + " " + line)
+		}
+	}
+	out := notes[:0]
+	for _, note := range notes {
+		if note != "" {
+			out = append(out, note)
+		}
+	}
+	return out
+}
+
+// sourceRef formats path:line with path relative to the working directory when it is below it.
+func sourceRef(path string, line int) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, abs); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				path = rel
+			}
+		}
+	}
+	return fmt.Sprintf("%s:%d", filepath.ToSlash(path), line)
+}
+
+// withSourceNotes returns the entry comments with the notes extracted from Go. For every key found in
+// the scanned code, "msgcat:" lines from an earlier extract are replaced by the current notes; other
+// comment lines are kept above them.
+func withSourceNotes(comments map[string]string, keys []string, notes map[string][]string) map[string]string {
+	out := make(map[string]string, len(comments))
+	for key, comment := range comments {
+		out[key] = comment
+	}
+	for _, key := range keys {
+		comment, ok := out[key]
+		if !ok && len(notes[key]) == 0 {
+			continue
+		}
+		var lines []string
+		if comment != "" {
+			for _, line := range strings.Split(comment, "\n") {
+				if !strings.HasPrefix(line, notePrefix) {
+					lines = append(lines, line)
+				}
+			}
+		}
+		for _, note := range notes[key] {
+			lines = append(lines, notePrefix+" "+note)
+		}
+		if len(lines) == 0 {
+			delete(out, key)
+			continue
+		}
+		out[key] = strings.Join(lines, "\n")
+	}
+	return out
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -65,6 +65,10 @@ func (e *keyExtractor) extractMessageDefFromCompositeLit(cl *ast.CompositeLit) (
 	if c, ok := data["Code"]; ok {
 		raw.Code = codeFromValue(c)
 	}
+	raw.Status, _ = data["Status"].(int)
+	raw.Description, _ = data["Description"].(string)
+	raw.Context, _ = data["Context"].(string)
+	raw.MaxLength, _ = data["MaxLength"].(int)
 	return key, raw
 }
 
@@ -69,7 +69,7 @@ set:
 	goSrc := []byte(`
 package p
 import "github.com/loopcontext/msgcat"
-var _ = msgcat.MessageDef{Key: "person.cats", Short: "Cats", Long: "Cats count"}
+var _ = msgcat.MessageDef{Key: "person.cats", Short: "Cats", Long: "Cats count", Status: 404}
 `)
 	goPath := filepath.Join(dir, "p.go")
 	if err := os.WriteFile(goPath, goSrc, 0644); err != nil {
@@ -81,7 +81,7 @@ var _ = msgcat.MessageDef{Key: "person.cats", Short: "Cats", Long: "Cats count"}
 		t.Fatal(err)
 	}
 	keys := ext.sortedKeys()
-	if err := runExtractSync(cfg, keys, ext.defs); err != nil {
+	if err := runExtractSync(cfg, keys, ext.defs, ext.notes); err != nil {
 		t.Fatal(err)
 	}
 	out, err := os.ReadFile(cfg.out)
@@ -95,4 +95,106 @@ var _ = msgcat.MessageDef{Key: "person.cats", Short: "Cats", Long: "Cats count"}
 	if !strings.Contains(content, "Cats") {
 		t.Errorf("expected Short/Long in output: %s", content)
 	}
+	if !strings.Contains(content, "status: 404") {
+		t.Errorf("expected status in output: %s", content)
+	}
+}
+
+func TestExtractMessageDef_translatorNotes(t *testing.T) {
+	dir := t.TempDir()
+	enPath := filepath.Join(dir, "en.yaml")
+	writeTestFile(t, enPath, `default:
+  short: Err
+  long: Err
+set:
+  checkout.pay:
+    short: Pay
+    context: Written by a translator in YAML
+  checkout.cancel:
+    short: Cancel
+    max_length: 12
+`)
+	goSrc := []byte(`
+package p
+import "github.com/loopcontext/msgcat"
+var _ = msgcat.MessageDef{Key: "checkout.pay", Short: "Pay now", Description: "Button label", MaxLength: 20}
+var _ = msgcat.MessageDef{Key: "checkout.cancel", Short: "Cancel", Context: "Checkout footer"}
+`)
+	ext := newKeyExtractor("github.com/loopcontext/msgcat")
+	if err := ext.extractFromFile(filepath.Join(dir, "p.go"), goSrc); err != nil {
+		t.Fatal(err)
+	}
+	if err := runExtractSync(&extractConfig{source: enPath}, ext.sortedKeys(), ext.defs, ext.notes); err != nil {
+		t.Fatal(err)
+	}
+	en, err := readLangFile("en", enPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pay := en.messages.Set["checkout.pay"]
+	if pay.ShortTpl != "Pay now" || pay.Description != "Button label" || pay.MaxLength != 20 || pay.Context != "Written by a translator in YAML" {
+		t.Errorf("checkout.pay = %+v", pay)
+	}
+	cancel := en.messages.Set["checkout.cancel"]
+	if cancel.Context != "Checkout footer" || cancel.MaxLength != 12 {
+		t.Errorf("checkout.cancel = %+v", cancel)
+	}
+}
+
+func TestExtractMessageDef_sourceNotes(t *testing.T) {
+	dir := t.TempDir()
+	enPath := filepath.Join(dir, "en.yaml")
+	writeTestFile(t, enPath, `default:
+  short: Err
+  long: Err
+set:
+  # Reviewed by the copy team
+  # msgcat: old note (old.go:1)
+  cart.empty:
+    short: Your cart is empty
+  # msgcat: kept, key not in scanned code (other.go:3)
+  other.key:
+    short: Other
+`)
+	goPath := filepath.Join(dir, "cart.go")
+	goSrc := []byte(`package p
+import "github.com/loopcontext/msgcat"
+func view() {
+	// msgcat: shown when the cart is empty
+	_ = catalog.GetMessageWithCtx(ctx, "cart.empty", nil)
+}
+// msgcat: button label, max 20 chars
+var pay = msgcat.MessageDef{Key: "checkout.pay", Short: "Pay now"}
+`)
+	for i := 0; i < 2; i++ {
+		ext := newKeyExtractor("github.com/loopcontext/msgcat")
+		if err := ext.extractFromFile(goPath, goSrc); err != nil {
+			t.Fatal(err)
+		}
+		if err := runExtractSync(&extractConfig{source: enPath}, ext.sortedKeys(), ext.defs, ext.notes); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(enPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments, err := readKeyComments(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := filepath.ToSlash(goPath)
+	want := map[string]string{
+		"cart.empty":   "Reviewed by the copy team\nmsgcat: shown when the cart is empty (" + ref + ":5)",
+		"checkout.pay": "msgcat: button label, max 20 chars (" + ref + ":8)",
+		"other.key":    "msgcat: kept, key not in scanned code (other.go:3)",
+	}
+	for key, comment := range want {
+		if comments[key] != comment {
+			t.Errorf("comment of %s = %q, want %q", key, comments[key], comment)
+		}
+	}
+	if len(comments) != len(want) {
+		t.Errorf("comments = %q", comments)
+	}
 }
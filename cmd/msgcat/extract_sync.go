@@ -5,29 +5,33 @@ import (
 	"os"
 
 	"github.com/loopcontext/msgcat"
-	"gopkg.in/yaml.v2"
 )
 
 // runExtractSync reads the source YAML, merges in keys (empty short/long if missing) and
-// defs (MessageDef content from Go), preserves group and default, writes to cfg.out.
-func runExtractSync(cfg *extractConfig, keys []string, defs map[string]msgcat.RawMessage) error {
+// defs (MessageDef content from Go), preserves group and default, writes to cfg.out in the
+// source's key style (flat or nested) and entry comments. notes ("// msgcat:" comments from Go)
+// replace the extracted comment lines of their keys.
+func runExtractSync(cfg *extractConfig, keys []string, defs map[string]msgcat.RawMessage, notes map[string][]string) error {
 	src, err := os.ReadFile(cfg.source)
 	if err != nil {
 		return fmt.Errorf("read source %s: %w", cfg.source, err)
 	}
-	var m msgcat.Messages
-	if err := yaml.Unmarshal(src, &m); err != nil {
+	m, nested, err := readMessagesYAML(src)
+	if err != nil {
 		return fmt.Errorf("parse source YAML: %w", err)
 	}
 	if m.Set == nil {
 		m.Set = make(map[string]msgcat.RawMessage)
 	}
 	added := 0
-	// MessageDef from Go: add or overwrite by key
+	// MessageDef from Go: add or overwrite by key, keeping translator notes only set in YAML
 	for key, raw := range defs {
 		if key == "" {
 			continue
 		}
+		if existing, ok := m.Set[key]; ok {
+			raw = withEntryNotes(raw, existing)
+		}
 		m.Set[key] = raw
 		added++
 	}
@@ -46,7 +50,15 @@ func runExtractSync(cfg *extractConfig, keys []string, defs map[string]msgcat.Ra
 	if outPath == "" {
 		outPath = cfg.source
 	}
-	out, err := yaml.Marshal(&m)
+	comments, err := readKeyComments(src)
+	if err != nil {
+		return fmt.Errorf("parse source YAML: %w", err)
+	}
+	comments = withSourceNotes(comments, keys, notes)
+	out, err := marshalMessagesYAML(&m, nested)
+	if err == nil {
+		out, err = withKeyComments(out, comments)
+	}
 	if err != nil {
 		return fmt.Errorf("marshal YAML: %w", err)
 	}
@@ -58,3 +70,17 @@ func runExtractSync(cfg *extractConfig, keys []string, defs map[string]msgcat.Ra
 	}
 	return nil
 }
+
+// withEntryNotes fills description, context and max_length of entry from notes where entry has none.
+func withEntryNotes(entry, notes msgcat.RawMessage) msgcat.RawMessage {
+	if entry.Description == "" {
+		entry.Description = notes.Description
+	}
+	if entry.Context == "" {
+		entry.Context = notes.Context
+	}
+	if entry.MaxLength == 0 {
+		entry.MaxLength = notes.MaxLength
+	}
+	return entry
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"reflect"
 	"sort"
 	"testing"
 )
@@ -32,6 +33,26 @@ func main() {
 	}
 }
 
+func TestKeyExtractor_newError(t *testing.T) {
+	src := []byte(`
+package domain
+import (
+	"errors"
+	mc "github.com/loopcontext/msgcat"
+)
+var ErrNotFound = mc.NewError("error.not_found", nil, nil)
+var other = errors.NewError("ignored.key")
+`)
+	ext := newKeyExtractor("github.com/loopcontext/msgcat")
+	if err := ext.extractFromFile("test.go", src); err != nil {
+		t.Fatal(err)
+	}
+	keys := ext.sortedKeys()
+	if len(keys) != 1 || keys[0] != "error.not_found" {
+		t.Errorf("got keys %v, want [error.not_found]", keys)
+	}
+}
+
 func TestKeyExtractor_skipsFilesWithoutMsgcatImport(t *testing.T) {
 	src := []byte(`
 package main
@@ -70,6 +91,44 @@ func main() {
 	}
 }
 
+func TestKeyExtractor_notes(t *testing.T) {
+	src := []byte(`package main
+import "github.com/loopcontext/msgcat"
+func main() {
+	// msgcat: shown when the cart is empty
+	_ = catalog.GetMessageWithCtx(ctx, "cart.empty", nil)
+	// Unrelated comment.
+	//
+	// msgcat: error banner on checkout,
+	// above the pay button
+	// msgcat: keep it short
+	_ = catalog.GetErrorWithCtx(ctx, "checkout.failed", nil)
+	_ = 1 // msgcat: trailing comment of the line above
+	_ = catalog.GetMessageWithCtx(ctx, "no.note", nil)
+	// msgcat: not directly above
+
+	_ = catalog.GetMessageWithCtx(ctx, "cart.empty", nil)
+}
+
+var defs = []msgcat.MessageDef{
+	/* msgcat: title of the orders page */
+	msgcat.MessageDef{Key: "orders.title", Short: "Orders"},
+}
+`)
+	ext := newKeyExtractor("github.com/loopcontext/msgcat")
+	if err := ext.extractFromFile("app/cart.go", src); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"cart.empty":      {"shown when the cart is empty (app/cart.go:5)"},
+		"checkout.failed": {"error banner on checkout, above the pay button (app/cart.go:11)", "keep it short (app/cart.go:11)"},
+		"orders.title":    {"title of the orders page (app/cart.go:21)"},
+	}
+	if !reflect.DeepEqual(ext.notes, want) {
+		t.Errorf("notes = %q, want %q", ext.notes, want)
+	}
+}
+
 func TestUnquote(t *testing.T) {
 	tests := []struct {
 		in   string
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/loopcontext/msgcat"
+)
+
+// importConfig holds flags for the import command.
+type importConfig struct {
+	format     string
+	source     string
+	sourceLang string
+	targetDir  string
+	dryRun     bool
+	inputs     []string
+}
+
+// importUpdate is the imported translation of one key. Empty text and forms leave the existing value
+// unchanged, so untranslated entries never erase translations.
+type importUpdate struct {
+	short      string
+	long       string
+	shortForms map[string]string
+	longForms  map[string]string
+	comment    string
+	state      string // Translation state (new, translated, reviewed); empty leaves it unchanged.
+}
+
+// importFormats maps -format values to importers; each returns updates by language, then by key.
+var importFormats = map[string]func(cfg *importConfig, path string) (map[string]map[string]importUpdate, error){
+	"csv":   importCSV,
+	"po":    importPO,
+	"xliff": importXLIFF,
+}
+
+func usageImport() {
+	fmt.Fprintf(os.Stderr, `usage: msgcat import -format <format> -source <file> [options] files...
+
+Import applies translations from exchange files back into <lang>.yaml files in -targetDir. Only
+keys present in the source file are imported; new entries take code, status and plural_param from
+the source. Existing code, group, default and entry comments are preserved, and each file keeps its
+key style (flat or nested). Use -dryRun to review the changes before writing.
+
+Formats:
+  csv    spreadsheets exported with -format csv; every <lang>.<field> column is applied to <lang>.yaml
+         (the source language column to the source file). Empty cells and the code/group columns are
+         ignored.
+  po     gettext .po files (language from the Language header or the file name; fuzzy entries skipped).
+  xliff  XLIFF 1.2 or 2.0 files (language from target-language/trgLang or the file name); segments in
+         a new/initial state are skipped and the translation state is stored in the entry's state.
+
+Flags:
+`)
+	flag.CommandLine.PrintDefaults()
+}
+
+func parseImportFlags(args []string) (*importConfig, error) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	fs.Usage = usageImport
+	var cfg importConfig
+	fs.StringVar(&cfg.format, "format", "", "Input format: "+strings.Join(formatNames(importFormats), ", ")+". Required.")
+	fs.StringVar(&cfg.source, "source", "", "Source message file (e.g. resources/messages/en.yaml). Required.")
+	fs.StringVar(&cfg.sourceLang, "sourceLang", "", "Source language (default: inferred from the source file name).")
+	fs.StringVar(&cfg.targetDir, "targetDir", "", "Directory of the <lang>.yaml files to update (default: same dir as source).")
+	fs.BoolVar(&cfg.dryRun, "dryRun", false, "Print the changes per file and key instead of writing the files.")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	cfg.inputs = fs.Args()
+	return &cfg, nil
+}
+
+func runImport(cfg *importConfig) error {
+	importer, ok := importFormats[cfg.format]
+	if !ok {
+		return fmt.Errorf("import: unknown -format %q (want %s)", cfg.format, strings.Join(formatNames(importFormats), ", "))
+	}
+	if cfg.source == "" {
+		return fmt.Errorf("import: -source is required")
+	}
+	if len(cfg.inputs) == 0 {
+		return fmt.Errorf("import: no input files")
+	}
+	if cfg.sourceLang == "" {
+		cfg.sourceLang = langFromPath(cfg.source)
+	}
+	if cfg.targetDir == "" {
+		cfg.targetDir = filepath.Dir(cfg.source)
+	}
+	source, err := readLangFile(cfg.sourceLang, cfg.source)
+	if err != nil {
+		return fmt.Errorf("read source: %w", err)
+	}
+	updates := map[string]map[string]importUpdate{}
+	for _, input := range cfg.inputs {
+		byLang, err := importer(cfg, input)
+		if err != nil {
+			return fmt.Errorf("import %s: %w", input, err)
+		}
+		for lang, byKey := range byLang {
+			if updates[lang] == nil {
+				updates[lang] = map[string]importUpdate{}
+			}
+			for key, update := range byKey {
+				updates[lang][key] = update
+			}
+		}
+	}
+	langs := make([]string, 0, len(updates))
+	for lang := range updates {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		target, err := readImportTarget(cfg, source, lang)
+		if err != nil {
+			return err
+		}
+		changes := applyImport(source, target, updates[lang])
+		if cfg.dryRun {
+			printImportDiff(os.Stdout, target.path, changes)
+			continue
+		}
+		if len(changes) == 0 {
+			fmt.Fprintf(os.Stderr, "msgcat: no changes for %s\n", target.path)
+			continue
+		}
+		if err := target.write(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "msgcat: updated %d key(s) in %s\n", changedKeys(changes), target.path)
+	}
+	return nil
+}
+
+// readImportTarget reads <lang>.yaml from the target dir, or starts one with the source's group,
+// default and key style when it does not exist.
+func readImportTarget(cfg *importConfig, source *langFile, lang string) (*langFile, error) {
+	path := filepath.Join(cfg.targetDir, lang+".yaml")
+	if lang == cfg.sourceLang {
+		path = cfg.source
+	}
+	target, err := readLangFile(lang, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &langFile{
+			lang: lang,
+			path: path,
+			messages: msgcat.Messages{
+				Group:   source.messages.Group,
+				Default: source.messages.Default,
+				Set:     map[string]msgcat.RawMessage{},
+			},
+			nested:   source.nested,
+			comments: map[string]string{},
+		}, nil
+	}
+	return target, err
+}
+
+// importChange is one field changed by an import, listed by -dryRun.
+type importChange struct {
+	key   string
+	field string // short, long, short_forms.<form>, long_forms.<form>, state or comment
+	old   string
+	new   string
+}
+
+// applyImport applies updates for keys known to the source and returns the changed fields. Only
+// entries with at least one changed field are stored.
+func applyImport(source, target *langFile, updates map[string]importUpdate) []importChange {
+	keys := make([]string, 0, len(updates))
+	for key := range updates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var changes []importChange
+	for _, key := range keys {
+		srcEntry, ok := source.messages.Set[key]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "msgcat: skipping %q: not in source %s\n", key, source.path)
+			continue
+		}
+		update := updates[key]
+		before := len(changes)
+		entry, exists := target.messages.Set[key]
+		if !exists {
+			entry = msgcat.RawMessage{Code: srcEntry.Code, Status: srcEntry.Status, PluralParam: srcEntry.PluralParam}
+		}
+		set := func(field string, dst *string, text string) {
+			if text != "" && text != *dst {
+				changes = append(changes, importChange{key: key, field: field, old: *dst, new: text})
+				*dst = text
+			}
+		}
+		set("short", &entry.ShortTpl, update.short)
+		set("long", &entry.LongTpl, update.long)
+		entry.ShortForms = applyImportForms(set, "short_forms.", entry.ShortForms, update.shortForms)
+		entry.LongForms = applyImportForms(set, "long_forms.", entry.LongForms, update.longForms)
+		set("state", &entry.State, update.state)
+		if update.comment != "" && update.comment != target.comments[key] {
+			changes = append(changes, importChange{key: key, field: "comment", old: target.comments[key], new: update.comment})
+			target.comments[key] = update.comment
+		}
+		// Untranslated updates (e.g. msgstr "") change nothing and must not add empty entries.
+		if len(changes) > before {
+			target.messages.Set[key] = entry
+		}
+	}
+	return changes
+}
+
+// applyImportForms applies the non-empty forms of update in form order through set.
+func applyImportForms(set func(field string, dst *string, text string), prefix string, forms, update map[string]string) map[string]string {
+	names := make([]string, 0, len(update))
+	for form := range update {
+		names = append(names, form)
+	}
+	sort.Strings(names)
+	for _, form := range names {
+		text := forms[form]
+		set(prefix+form, &text, update[form])
+		if text != "" {
+			if forms == nil {
+				forms = map[string]string{}
+			}
+			forms[form] = text
+		}
+	}
+	return forms
+}
+
+// changedKeys counts the distinct keys in changes.
+func changedKeys(changes []importChange) int {
+	n := 0
+	for i, change := range changes {
+		if i == 0 || change.key != changes[i-1].key {
+			n++
+		}
+	}
+	return n
+}
+
+// printImportDiff writes the changes an import would make to one file.
+func printImportDiff(w io.Writer, path string, changes []importChange) {
+	fmt.Fprintf(w, "%s: %d key(s) would change\n", path, changedKeys(changes))
+	for i, change := range changes {
+		if i == 0 || change.key != changes[i-1].key {
+			fmt.Fprintf(w, "  %s\n", change.key)
+		}
+		fmt.Fprintf(w, "    %s: %q -> %q\n", change.field, change.old, change.new)
+	}
+}
+
+// mergeForms sets the non-empty forms of update on forms.
+func mergeForms(forms, update map[string]string) map[string]string {
+	for form, text := range update {
+		if text == "" {
+			continue
+		}
+		if forms == nil {
+			forms = map[string]string{}
+		}
+		forms[form] = text
+	}
+	return forms
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// exportIOS writes <lang>.lproj/<name>.strings for plain messages and <name>.stringsdict for messages
+// with forms. Keys are kept as is; the long text uses "<key>.long".
+func exportIOS(cfg *exportConfig, source *langFile, targets []*langFile) error {
+	byLang, skipped := mobileMessages(source, targets, iosName)
+	for _, file := range append([]*langFile{source}, targets...) {
+		dir := iosLprojDir(file.lang)
+		var plain, plurals []*mobileMessage
+		for _, msg := range byLang[file.lang] {
+			if msg.forms == nil {
+				plain = append(plain, msg)
+			} else {
+				plurals = append(plurals, msg)
+			}
+		}
+		if err := cfg.writeExport(filepath.Join(dir, cfg.name+".strings"), iosStrings(source, plain)); err != nil {
+			return err
+		}
+		if len(plurals) > 0 {
+			if err := cfg.writeExport(filepath.Join(dir, cfg.name+".stringsdict"), iosStringsdict(plurals)); err != nil {
+				return err
+			}
+		}
+	}
+	reportMobileSkips("ios", skipped)
+	return nil
+}
+
+func iosName(key string, long bool) string {
+	if long {
+		return key + ".long"
+	}
+	return key
+}
+
+// iosLprojDir returns the bundle directory for a language (e.g. "pt-br" -> "pt-BR.lproj").
+func iosLprojDir(lang string) string {
+	language, script, region := localeParts(lang)
+	return joinLocale("-", language, script, region) + ".lproj"
+}
+
+func iosStrings(source *langFile, messages []*mobileMessage) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "/* Generated by msgcat export from %s. */\n", iosComment(filepath.Base(source.path)))
+	for _, msg := range messages {
+		fmt.Fprintf(&buf, "\n/* %s */\n", iosComment(mobileComment(msg)))
+		fmt.Fprintf(&buf, "\"%s\" = \"%s\";\n", iosEscape(msg.name, false), iosText(msg, msg.parts, iosEscape))
+	}
+	return buf.Bytes()
+}
+
+// iosStringsdict renders plural messages as a property list; the plural param is argument 1 and
+// drives the variable of NSStringLocalizedFormatKey.
+func iosStringsdict(messages []*mobileMessage) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	plistEscape := func(s string, formatted bool) string { return xmlText(iosEscapePercent(s, formatted)) }
+	for _, msg := range messages {
+		variable := strings.ReplaceAll(msg.params[0].name, ".", "_")
+		fmt.Fprintf(&buf, "\t<!-- %s -->\n", xmlComment(mobileComment(msg)))
+		fmt.Fprintf(&buf, "\t<key>%s</key>\n\t<dict>\n", xmlText(msg.name))
+		fmt.Fprintf(&buf, "\t\t<key>NSStringLocalizedFormatKey</key>\n\t\t<string>%%1$#@%s@</string>\n", variable)
+		fmt.Fprintf(&buf, "\t\t<key>%s</key>\n\t\t<dict>\n", variable)
+		buf.WriteString("\t\t\t<key>NSStringFormatSpecTypeKey</key>\n\t\t\t<string>NSStringPluralRuleType</string>\n")
+		buf.WriteString("\t\t\t<key>NSStringFormatValueTypeKey</key>\n\t\t\t<string>ld</string>\n")
+		for _, form := range msg.formNames() {
+			fmt.Fprintf(&buf, "\t\t\t<key>%s</key>\n\t\t\t<string>%s</string>\n", form, iosText(msg, msg.forms[form], plistEscape))
+		}
+		buf.WriteString("\t\t</dict>\n\t</dict>\n")
+	}
+	buf.WriteString("</dict>\n</plist>\n")
+	return buf.Bytes()
+}
+
+// iosText renders parts with %n$@ arguments (%n$ld for the plural count).
+func iosText(msg *mobileMessage, parts []mobilePart, escape func(s string, formatted bool) string) string {
+	formatted := len(msg.params) > 0
+	return renderParts(parts, func(s string) string { return escape(s, formatted) }, func(index int) string {
+		if msg.params[index-1].kind == paramCount {
+			return fmt.Sprintf("%%%d$ld", index)
+		}
+		return fmt.Sprintf("%%%d$@", index)
+	})
+}
+
+// iosEscape escapes s for a quoted .strings value; '%' is doubled only when the string takes arguments.
+func iosEscape(s string, formatted bool) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`).Replace(s)
+	return iosEscapePercent(s, formatted)
+}
+
+func iosEscapePercent(s string, formatted bool) string {
+	if formatted {
+		return strings.ReplaceAll(s, "%", "%%")
+	}
+	return s
+}
+
+// iosComment makes s safe inside /* */.
+func iosComment(s string) string {
+	return strings.ReplaceAll(s, "*/", "* /")
+}
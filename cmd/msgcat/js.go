@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/loopcontext/msgcat"
+)
+
+// jsEntry is one message in a JSON bundle; field names match the YAML schema.
+type jsEntry struct {
+	Short       string            `json:"short,omitempty"`
+	Long        string            `json:"long,omitempty"`
+	ShortForms  map[string]string `json:"short_forms,omitempty"`
+	LongForms   map[string]string `json:"long_forms,omitempty"`
+	PluralParam string            `json:"plural_param,omitempty"`
+	Code        string            `json:"code,omitempty"`
+	Status      int               `json:"status,omitempty"`
+}
+
+// jsParamTypes maps placeholder kinds to TypeScript types, from the most to the least specific.
+var jsParamTypes = []struct{ kind, tsType string }{
+	{paramCount, "number"},
+	{paramNum, "number"},
+	{paramDate, "Date | string | number"},
+	{paramText, "string | number"},
+}
+
+// exportJS writes <name>.<lang>.json bundles for the source and each target, and <name>.d.ts with the
+// keys and their params. Every file of a language is read (see readLanguageFiles) and, with -group,
+// only files in that group are kept. Bundles have every source key: entries missing from a
+// translation use the source entry, as the catalog falls back at runtime.
+func exportJS(cfg *exportConfig, source *langFile, targets []*langFile) error {
+	srcSet, err := jsLanguageSet(source, cfg.group)
+	if err != nil {
+		return err
+	}
+	if len(srcSet) == 0 && cfg.group != "" {
+		return fmt.Errorf("export: no %s messages in group %q", source.lang, cfg.group)
+	}
+	for _, file := range append([]*langFile{source}, targets...) {
+		set := srcSet
+		if file != source {
+			if set, err = jsLanguageSet(file, cfg.group); err != nil {
+				return err
+			}
+		}
+		bundle := make(map[string]jsEntry, len(srcSet))
+		for key, srcEntry := range srcSet {
+			entry, ok := set[key]
+			if !ok {
+				entry = srcEntry
+			}
+			bundle[key] = jsEntry{
+				Short:       entry.ShortTpl,
+				Long:        entry.LongTpl,
+				ShortForms:  entry.ShortForms,
+				LongForms:   entry.LongForms,
+				PluralParam: entry.PluralParam,
+				Code:        string(entry.Code),
+				Status:      entry.Status,
+			}
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(bundle); err != nil {
+			return err
+		}
+		if err := cfg.writeExport(cfg.name+"."+file.lang+".json", buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return cfg.writeExport(cfg.name+".d.ts", jsTypes(cfg, source, srcSet))
+}
+
+// jsLanguageSet merges the entries of a language's files (in the group, when set). file is read
+// even if it does not follow the <lang>.yaml naming; a target without files has an empty set.
+func jsLanguageSet(file *langFile, group string) (map[string]msgcat.RawMessage, error) {
+	files, err := readLanguageFiles(filepath.Dir(file.path), file.lang)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, f := range files {
+		found = found || filepath.Clean(f.path) == filepath.Clean(file.path)
+	}
+	if !found {
+		files = append([]*langFile{file}, files...)
+	}
+	set := map[string]msgcat.RawMessage{}
+	from := map[string]string{}
+	for _, f := range files {
+		if group != "" && string(f.messages.Group) != group {
+			continue
+		}
+		for key, entry := range f.messages.Set {
+			if prev, ok := from[key]; ok {
+				return nil, fmt.Errorf("duplicate message key %q for language %s in %s and %s", key, file.lang, prev, f.path)
+			}
+			from[key] = f.path
+			set[key] = entry
+		}
+	}
+	return set, nil
+}
+
+// jsParams returns the params used by an entry with the most specific kind seen for each.
+func jsParams(entry msgcat.RawMessage) map[string]string {
+	params := map[string]string{}
+	rank := func(kind string) int {
+		for i, t := range jsParamTypes {
+			if t.kind == kind {
+				return i
+			}
+		}
+		return len(jsParamTypes)
+	}
+	add := func(name, kind string) {
+		if prev, ok := params[name]; !ok || rank(kind) < rank(prev) {
+			params[name] = kind
+		}
+	}
+	texts := []string{entry.ShortTpl, entry.LongTpl}
+	for _, forms := range []map[string]string{entry.ShortForms, entry.LongForms} {
+		for _, text := range forms {
+			texts = append(texts, text)
+		}
+	}
+	if len(entry.ShortForms) > 0 || len(entry.LongForms) > 0 {
+		pluralParam := entry.PluralParam
+		if pluralParam == "" {
+			pluralParam = "count"
+		}
+		add(pluralParam, paramCount)
+	}
+	for _, text := range texts {
+		for _, match := range mobilePluralRegex.FindAllStringSubmatch(text, -1) {
+			add(match[1], paramCount)
+		}
+		for _, match := range mobilePlaceholderRegex.FindAllStringSubmatch(text, -1) {
+			add(match[2], match[1])
+		}
+	}
+	return params
+}
+
+// jsTypes renders the TypeScript declarations: MessageParams maps every key to its params.
+func jsTypes(cfg *exportConfig, source *langFile, set map[string]msgcat.RawMessage) []byte {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Generated by msgcat export from %s", filepath.Base(source.path))
+	if cfg.group != "" {
+		fmt.Fprintf(&buf, " (group %s)", cfg.group)
+	}
+	buf.WriteString(". Do not edit.\n\n")
+	buf.WriteString("/** Params of each message key. */\nexport interface MessageParams {\n")
+	for _, key := range keys {
+		params := jsParams(set[key])
+		if len(params) == 0 {
+			fmt.Fprintf(&buf, "  %s: Record<string, never>;\n", strconv.Quote(key))
+			continue
+		}
+		names := make([]string, 0, len(params))
+		for name := range params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fields := make([]string, len(names))
+		for i, name := range names {
+			tsType := "string | number"
+			for _, t := range jsParamTypes {
+				if t.kind == params[name] {
+					tsType = t.tsType
+				}
+			}
+			fields[i] = strconv.Quote(name) + ": " + tsType
+		}
+		fmt.Fprintf(&buf, "  %s: { %s };\n", strconv.Quote(key), strings.Join(fields, "; "))
+	}
+	buf.WriteString(`}
+
+export type MessageKey = keyof MessageParams;
+
+export type PluralForm = "zero" | "one" | "two" | "few" | "many" | "other";
+
+/** One entry of a ` + cfg.name + `.<lang>.json bundle. */
+export interface MessageEntry {
+  short?: string;
+  long?: string;
+  short_forms?: Partial<Record<PluralForm, string>>;
+  long_forms?: Partial<Record<PluralForm, string>>;
+  plural_param?: string;
+  code?: string;
+  status?: number;
+}
+
+export type MessageBundle = Record<MessageKey, MessageEntry>;
+`)
+	return buf.Bytes()
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeJSTestFiles(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "en.yaml"), `group: api
+default: {short: Err, long: Err}
+set:
+  error.not_found:
+    code: 404
+    short: Not found
+`)
+	writeTestFile(t, filepath.Join(dir, "en.web.yaml"), `group: web
+default: {short: Err, long: Err}
+set:
+  greeting.hello:
+    short: Hello {{name}} <b>
+    long: You owe {{num:amount}} since {{date:since}}
+  person.cats:
+    short_forms: {one: "{{count}} cat", other: "{{count}} cats"}
+  plain:
+    short: Plain
+`)
+	writeTestFile(t, filepath.Join(dir, "es.yaml"), `group: api
+default: {short: Error, long: Error}
+set:
+  error.not_found:
+    short: No encontrado
+`)
+	if err := os.Mkdir(filepath.Join(dir, "es"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, "es", "web.yaml"), `group: web
+default: {short: Error, long: Error}
+set:
+  greeting.hello:
+    short: Hola {{name}}
+`)
+	return dir
+}
+
+func readJSBundle(t *testing.T, path string) map[string]jsEntry {
+	t.Helper()
+	var bundle map[string]jsEntry
+	if err := json.Unmarshal([]byte(readTestFile(t, path)), &bundle); err != nil {
+		t.Fatal(err)
+	}
+	return bundle
+}
+
+func TestExportJS_group(t *testing.T) {
+	dir := writeJSTestFiles(t)
+	out := filepath.Join(dir, "out")
+	if err := runExport(&exportConfig{format: "js", source: filepath.Join(dir, "en.yaml"), outdir: out, group: "web"}); err != nil {
+		t.Fatal(err)
+	}
+	es := readJSBundle(t, filepath.Join(out, "messages.es.json"))
+	want := map[string]jsEntry{
+		"greeting.hello": {Short: "Hola {{name}}"},
+		"person.cats":    {ShortForms: map[string]string{"one": "{{count}} cat", "other": "{{count}} cats"}},
+		"plain":          {Short: "Plain"},
+	}
+	if !reflect.DeepEqual(es, want) {
+		t.Errorf("es bundle = %+v, want %+v", es, want)
+	}
+	if en := readTestFile(t, filepath.Join(out, "messages.en.json")); !strings.Contains(en, `"short": "Hello {{name}} <b>"`) {
+		t.Errorf("en bundle should keep templates unescaped:\n%s", en)
+	}
+	assertContains(t, "messages.d.ts", readTestFile(t, filepath.Join(out, "messages.d.ts")),
+		"(group web)",
+		`  "greeting.hello": { "amount": number; "name": string | number; "since": Date | string | number };`,
+		`  "person.cats": { "count": number };`,
+		`  "plain": Record<string, never>;`,
+		"export type MessageKey = keyof MessageParams;",
+	)
+}
+
+func TestExportJS_allGroups(t *testing.T) {
+	dir := writeJSTestFiles(t)
+	out := filepath.Join(dir, "out")
+	if err := runExport(&exportConfig{format: "js", source: filepath.Join(dir, "en.yaml"), outdir: out}); err != nil {
+		t.Fatal(err)
+	}
+	es := readJSBundle(t, filepath.Join(out, "messages.es.json"))
+	if len(es) != 4 || es["error.not_found"].Short != "No encontrado" || es["greeting.hello"].Short != "Hola {{name}}" {
+		t.Errorf("es bundle = %+v", es)
+	}
+	if got := readJSBundle(t, filepath.Join(out, "messages.en.json"))["error.not_found"].Code; got != "404" {
+		t.Errorf("code = %q, want 404", got)
+	}
+
+	err := runExport(&exportConfig{format: "js", source: filepath.Join(dir, "en.yaml"), outdir: out, group: "mobile"})
+	if err == nil || !strings.Contains(err.Error(), `group "mobile"`) {
+		t.Errorf("err = %v, want no messages in group", err)
+	}
+}
+
+func TestExportJS_duplicateKey(t *testing.T) {
+	dir := writeJSTestFiles(t)
+	writeTestFile(t, filepath.Join(dir, "en.extra.yaml"), `group: web
+set:
+  plain:
+    short: Again
+`)
+	err := runExport(&exportConfig{format: "js", source: filepath.Join(dir, "en.yaml"), outdir: filepath.Join(dir, "out"), group: "web"})
+	if err == nil || !strings.Contains(err.Error(), `duplicate message key "plain"`) {
+		t.Errorf("err = %v, want duplicate key error", err)
+	}
+}
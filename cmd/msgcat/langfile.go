@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/loopcontext/msgcat"
+)
+
+// langFile is one language's message file as read and written by export and import.
+type langFile struct {
+	lang     string
+	path     string
+	messages msgcat.Messages
+	nested   bool
+	comments map[string]string // message key -> comment above the entry
+}
+
+// readLangFile reads a message file, keeping its key style and entry comments.
+func readLangFile(lang, path string) (*langFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m, nested, err := readMessagesYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if m.Set == nil {
+		m.Set = make(map[string]msgcat.RawMessage)
+	}
+	comments, err := readKeyComments(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if comments == nil {
+		comments = make(map[string]string)
+	}
+	return &langFile{lang: lang, path: path, messages: m, nested: nested, comments: comments}, nil
+}
+
+// write writes the file in its key style with entry comments.
+func (f *langFile) write() error {
+	out, err := marshalMessagesYAML(&f.messages, f.nested)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", f.path, err)
+	}
+	out, err = withKeyComments(out, f.comments)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", f.path, err)
+	}
+	if err := os.WriteFile(f.path, out, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// sortedKeys returns the message keys in the file, sorted.
+func (f *langFile) sortedKeys() []string {
+	keys := make([]string, 0, len(f.messages.Set))
+	for key := range f.messages.Set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// langFromPath infers the language from a file name (e.g. "es.po" or "translate.es.yaml" -> "es").
+func langFromPath(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if idx := strings.LastIndex(base, "."); idx >= 0 {
+		base = base[idx+1:]
+	}
+	return strings.TrimSpace(strings.ToLower(base))
+}
+
+// splitLangs parses a comma-separated list of language tags.
+func splitLangs(list string) []string {
+	var out []string
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(strings.ToLower(s))
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// readLanguageFiles reads every YAML file of a language in dir, in the layout the catalog loader
+// merges: <lang>.yaml, <lang>.<name>.yaml and <lang>/*.yaml. Files are sorted by path.
+func readLanguageFiles(dir, lang string) ([]*langFile, error) {
+	var paths []string
+	for _, pattern := range []string{lang + ".y*ml", lang + ".*.y*ml", filepath.Join(lang, "*.y*ml")} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if ext := filepath.Ext(match); ext == ".yaml" || ext == ".yml" {
+				paths = append(paths, match)
+			}
+		}
+	}
+	sort.Strings(paths)
+	files := make([]*langFile, 0, len(paths))
+	for _, path := range paths {
+		file, err := readLangFile(lang, path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
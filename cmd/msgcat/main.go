@@ -28,6 +28,27 @@ func main() {
 			break
 		}
 		err = runMerge(cfg)
+	case "export":
+		cfg, e := parseExportFlags(args)
+		if e != nil {
+			err = e
+			break
+		}
+		err = runExport(cfg)
+	case "import":
+		cfg, e := parseImportFlags(args)
+		if e != nil {
+			err = e
+			break
+		}
+		err = runImport(cfg)
+	case "validate":
+		cfg, e := parseValidateFlags(args)
+		if e != nil {
+			err = e
+			break
+		}
+		err = runValidate(cfg)
 	case "help", "-h", "--help":
 		usage()
 		os.Exit(0)
@@ -43,14 +64,17 @@ func main() {
 }
 
 func usage() {
-	fmt.Fprintf(os.Stderr, `msgcat - message catalog CLI for extract and merge workflow
+	fmt.Fprintf(os.Stderr, `msgcat - message catalog CLI for extract, merge, export, import and validate workflow
 
 usage: msgcat <command> [options] [paths]
 
 commands:
   extract    Discover message keys from Go code; optionally sync into source YAML.
   merge      Produce translate.<lang>.yaml files from a source message file.
+  export     Convert message files to a translation exchange format (e.g. gettext PO).
+  import     Apply translations from exchange files back into <lang>.yaml files.
+  validate   Check translations against entry rules (max_length).
 
-Use 'msgcat extract -h' or 'msgcat merge -h' for command-specific flags.
+Use 'msgcat <command> -h' for command-specific flags.
 `)
 }
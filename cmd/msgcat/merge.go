@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/loopcontext/msgcat"
-	"gopkg.in/yaml.v2"
 )
 
 // mergeConfig holds flags for the merge command.
@@ -26,7 +25,7 @@ func usageMerge() {
 Merge produces per-language translate files from a source message file. For each target
 language, writes translate.<lang>.yaml with every key from the source; keys missing or
 empty in the target use source short/long as placeholder. Copies source 'group' and
-'default' into each output file.
+'default' into each output file. Output uses the source's key style (flat or nested).
 
 Flags:
 `)
@@ -56,8 +55,8 @@ func runMerge(cfg *mergeConfig) error {
 	if err != nil {
 		return fmt.Errorf("read source: %w", err)
 	}
-	var source msgcat.Messages
-	if err := yaml.Unmarshal(srcContent, &source); err != nil {
+	source, nested, err := readMessagesYAML(srcContent)
+	if err != nil {
 		return fmt.Errorf("parse source YAML: %w", err)
 	}
 	if source.Set == nil {
@@ -98,8 +97,10 @@ func runMerge(cfg *mergeConfig) error {
 		if cfg.targetDir != "" {
 			targetPath = filepath.Join(cfg.targetDir, lang+".yaml")
 		}
+		var comments map[string]string
 		if tb, err := os.ReadFile(targetPath); err == nil {
-			_ = yaml.Unmarshal(tb, &target)
+			target, _, _ = readMessagesYAML(tb)
+			comments, _ = readKeyComments(tb)
 		}
 		if target.Set == nil {
 			target.Set = make(map[string]msgcat.RawMessage)
@@ -115,7 +116,10 @@ func runMerge(cfg *mergeConfig) error {
 			hasTpl := dstEntry.ShortTpl != "" && dstEntry.LongTpl != ""
 			hasForms := len(dstEntry.ShortForms) > 0 || len(dstEntry.LongForms) > 0
 			if hasTpl || hasForms {
-				merged.Set[key] = dstEntry
+				// Translator notes come from the source; the target's own notes fill what the source leaves empty.
+				entry := dstEntry
+				entry.Description, entry.Context, entry.MaxLength = srcEntry.Description, srcEntry.Context, srcEntry.MaxLength
+				merged.Set[key] = withEntryNotes(entry, dstEntry)
 			} else {
 				entry := msgcat.RawMessage{
 					ShortTpl:    srcEntry.ShortTpl,
@@ -124,11 +128,18 @@ func runMerge(cfg *mergeConfig) error {
 					ShortForms:  srcEntry.ShortForms,
 					LongForms:   srcEntry.LongForms,
 					PluralParam: srcEntry.PluralParam,
+					Status:      srcEntry.Status,
+					Description: srcEntry.Description,
+					Context:     srcEntry.Context,
+					MaxLength:   srcEntry.MaxLength,
 				}
 				merged.Set[key] = entry
 			}
 		}
-		out, err := yaml.Marshal(&merged)
+		out, err := marshalMessagesYAML(&merged, nested)
+		if err == nil {
+			out, err = withKeyComments(out, comments)
+		}
 		if err != nil {
 			return fmt.Errorf("marshal %s: %w", lang, err)
 		}
@@ -141,21 +152,12 @@ func runMerge(cfg *mergeConfig) error {
 }
 
 func (c *mergeConfig) targetLangsList() []string {
-	if c.targetLangs == "" {
-		return nil
-	}
-	var out []string
-	for _, s := range strings.Split(c.targetLangs, ",") {
-		s = strings.TrimSpace(strings.ToLower(s))
-		if s != "" {
-			out = append(out, s)
-		}
-	}
-	return out
+	return splitLangs(c.targetLangs)
 }
 
 func readTargetLangsFromDir(dir, sourcePath string) ([]string, error) {
 	sourceBase := filepath.Base(sourcePath)
+	sourceLang := strings.ToLower(strings.SplitN(sourceBase, ".", 2)[0])
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, err
@@ -173,9 +175,10 @@ func readTargetLangsFromDir(dir, sourcePath string) ([]string, error) {
 		if name == sourceBase || strings.HasPrefix(name, "translate.") {
 			continue
 		}
-		lang := strings.TrimSuffix(name, ".yaml")
+		// Extra files of a language (<lang>.<name>.yaml) belong to <lang>.
+		lang := strings.SplitN(strings.TrimSuffix(name, ".yaml"), ".", 2)[0]
 		lang = strings.TrimSpace(strings.ToLower(lang))
-		if lang == "" {
+		if lang == "" || lang == sourceLang {
 			continue
 		}
 		if _, ok := seen[lang]; ok {
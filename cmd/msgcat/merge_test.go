@@ -3,6 +3,8 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 )
@@ -64,3 +66,146 @@ set:
 		t.Errorf("merge should preserve target forms; got %s", content)
 	}
 }
+
+func TestMerge_keepsNestedKeyStyle(t *testing.T) {
+	dir := t.TempDir()
+	source := []byte(`default:
+  short: Err
+  long: Err
+set:
+  greeting:
+    hello:
+      short: Hello
+      long: Hello there
+    bye:
+      short: Bye
+      long: Goodbye
+`)
+	sourcePath := filepath.Join(dir, "en.yaml")
+	if err := os.WriteFile(sourcePath, source, 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Target uses flat keys; output still follows the nested source.
+	target := []byte(`default:
+  short: Error
+  long: Error
+set:
+  greeting.hello:
+    short: Hola
+    long: Hola a todos
+`)
+	if err := os.WriteFile(filepath.Join(dir, "es.yaml"), target, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runMerge(&mergeConfig{source: sourcePath, targetLangs: "es", outdir: dir}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.ReadFile(filepath.Join(dir, "translate.es.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, nested, err := readMessagesYAML(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !nested || strings.Contains(string(out), "greeting.hello") {
+		t.Errorf("merge output should keep nested keys; got %s", out)
+	}
+	if m.Set["greeting.hello"].ShortTpl != "Hola" || m.Set["greeting.bye"].ShortTpl != "Bye" {
+		t.Errorf("unexpected merged entries %+v", m.Set)
+	}
+}
+
+func TestMarshalMessagesYAML_flatRoundTrip(t *testing.T) {
+	src := []byte(`default:
+  short: Err
+  long: Err
+set:
+  greeting.hello:
+    short: Hello
+    long: Hello there
+  status.only:
+    code: ERR_X
+`)
+	m, nested, err := readMessagesYAML(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nested {
+		t.Fatal("flat source reported as nested")
+	}
+	if m.Set["status.only"].Code != "ERR_X" {
+		t.Errorf("code-only entry lost: %+v", m.Set)
+	}
+	out, err := marshalMessagesYAML(&m, nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "greeting.hello:") {
+		t.Errorf("flat output expected; got %s", out)
+	}
+}
+
+func TestReadTargetLangsFromDir_extraFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"en.yaml", "en.web.yaml", "es.yaml", "es.web.yaml", "fr.web.yaml", "translate.es.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("set: {}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	langs, err := readTargetLangsFromDir(dir, filepath.Join(dir, "en.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(langs)
+	if want := []string{"es", "fr"}; !reflect.DeepEqual(langs, want) {
+		t.Errorf("langs = %v, want %v", langs, want)
+	}
+}
+
+func TestMerge_copiesTranslatorNotes(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "en.yaml"), `default:
+  short: Err
+  long: Err
+set:
+  checkout.pay:
+    short: Pay
+    long: Pay now
+    description: Button label
+    context: Checkout page
+    max_length: 20
+  checkout.cancel:
+    short: Cancel
+    long: Cancel order
+    max_length: 12
+`)
+	writeTestFile(t, filepath.Join(dir, "es.yaml"), `default:
+  short: Error
+  long: Error
+set:
+  checkout.pay:
+    short: Pagar
+    long: Pagar ahora
+    description: Old note
+  checkout.cancel:
+    short: Cancelar
+    long: Cancelar pedido
+    context: Footer button
+`)
+	if err := runMerge(&mergeConfig{source: filepath.Join(dir, "en.yaml"), targetLangs: "es"}); err != nil {
+		t.Fatal(err)
+	}
+	es, err := readLangFile("es", filepath.Join(dir, "translate.es.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pay := es.messages.Set["checkout.pay"]
+	if pay.ShortTpl != "Pagar" || pay.Description != "Button label" || pay.Context != "Checkout page" || pay.MaxLength != 20 {
+		t.Errorf("checkout.pay = %+v", pay)
+	}
+	cancel := es.messages.Set["checkout.cancel"]
+	if cancel.Context != "Footer button" || cancel.MaxLength != 12 {
+		t.Errorf("checkout.cancel = %+v", cancel)
+	}
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/loopcontext/msgcat"
+)
+
+// Placeholder kinds of mobileParam.
+const (
+	paramText  = ""
+	paramNum   = "num"
+	paramDate  = "date"
+	paramCount = "count" // the plural param of a message with forms
+)
+
+var (
+	// mobilePlaceholderRegex matches {{name}}, {{num:name}} and {{date:name}}.
+	mobilePlaceholderRegex = regexp.MustCompile(`\{\{(?:(num|date):)?([a-zA-Z_][a-zA-Z0-9_.]*)\}\}`)
+	// mobilePluralRegex matches inline {{plural:param|...}} tokens (same syntax as the catalog).
+	mobilePluralRegex = regexp.MustCompile(`\{\{plural:([a-zA-Z_][a-zA-Z0-9_.]*)\|((?:[^{}]|\{\{.*?\}\})*)\}\}`)
+
+	cldrForms = []string{"zero", "one", "two", "few", "many", "other"}
+)
+
+// mobileParam is one argument of a converted message.
+type mobileParam struct {
+	name string
+	kind string
+}
+
+// mobilePart is a literal text or a reference to params[param-1].
+type mobilePart struct {
+	text  string
+	param int
+}
+
+// mobileMessage is one text of an entry (short or long) in one language, ready for a platform
+// renderer. Plural messages have forms (CLDR form -> parts) and their plural param as params[0].
+// params is taken from the source language, so argument positions match across languages.
+type mobileMessage struct {
+	key     string
+	long    bool
+	name    string // platform key
+	parts   []mobilePart
+	forms   map[string][]mobilePart
+	params  []mobileParam
+	comment string
+}
+
+// formNames returns the forms of m in CLDR order.
+func (m *mobileMessage) formNames() []string {
+	var names []string
+	for _, form := range cldrForms {
+		if _, ok := m.forms[form]; ok {
+			names = append(names, form)
+		}
+	}
+	return names
+}
+
+// mobileSkip is an entry that could not be converted for a language.
+type mobileSkip struct {
+	lang   string
+	key    string
+	reason string
+}
+
+// mobileNamer sanitizes a catalog key into a platform key; long is set for the long text.
+type mobileNamer func(key string, long bool) string
+
+// mobileMessages converts the source and target entries into per-language messages, in source key
+// order (short before long). Keys whose platform names collide are skipped for every language.
+func mobileMessages(source *langFile, targets []*langFile, name mobileNamer) (map[string][]*mobileMessage, []mobileSkip) {
+	files := append([]*langFile{source}, targets...)
+	byLang := make(map[string][]*mobileMessage, len(files))
+	var skipped []mobileSkip
+	used := map[string]string{}
+	for _, key := range source.sortedKeys() {
+		srcEntry := source.messages.Set[key]
+		for _, long := range []bool{false, true} {
+			srcText, srcForms := entryText(srcEntry, long)
+			if srcText == "" && len(srcForms) == 0 {
+				continue
+			}
+			platformName := name(key, long)
+			if other, ok := used[platformName]; ok {
+				skipped = append(skipped, mobileSkip{source.lang, textLabel(key, long), fmt.Sprintf("name %q already used by %s", platformName, other)})
+				continue
+			}
+			used[platformName] = textLabel(key, long)
+			params, srcPlural, err := mobileParams(srcText, srcForms, srcEntry.PluralParam)
+			if err != nil {
+				skipped = append(skipped, mobileSkip{source.lang, textLabel(key, long), err.Error()})
+				continue
+			}
+			for _, file := range files {
+				text, forms := entryText(file.messages.Set[key], long)
+				if text == "" && len(forms) == 0 {
+					continue
+				}
+				msg, err := convertMobileMessage(text, forms, srcPlural, params)
+				if err != nil {
+					skipped = append(skipped, mobileSkip{file.lang, textLabel(key, long), err.Error()})
+					continue
+				}
+				msg.key = key
+				msg.long = long
+				msg.name = platformName
+				msg.comment = file.comments[key]
+				byLang[file.lang] = append(byLang[file.lang], msg)
+			}
+		}
+	}
+	return byLang, skipped
+}
+
+func entryText(entry msgcat.RawMessage, long bool) (string, map[string]string) {
+	if long {
+		return entry.LongTpl, entry.LongForms
+	}
+	return entry.ShortTpl, entry.ShortForms
+}
+
+func textLabel(key string, long bool) string {
+	if long {
+		return key + " (long)"
+	}
+	return key
+}
+
+// pluralForms returns the forms of a text: forms as given, or forms expanded from inline
+// {{plural:param|...}} tokens. It returns nil forms for plain text.
+func pluralForms(text string, forms map[string]string, pluralParam string) (map[string]string, string, error) {
+	if len(forms) > 0 {
+		for _, form := range forms {
+			if mobilePluralRegex.MatchString(form) {
+				return nil, "", fmt.Errorf("plural token inside plural forms")
+			}
+		}
+		if pluralParam == "" {
+			pluralParam = "count"
+		}
+		return forms, pluralParam, nil
+	}
+	matches := mobilePluralRegex.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return nil, "", nil
+	}
+	param := matches[0][1]
+	branches := make([]map[string]string, len(matches))
+	for i, match := range matches {
+		if match[1] != param {
+			return nil, "", fmt.Errorf("plural tokens on several params (%s, %s)", param, match[1])
+		}
+		parts := strings.Split(match[2], "|")
+		branch := map[string]string{}
+		if len(parts) == 2 && !strings.Contains(parts[0], ":") {
+			branch["one"], branch["other"] = parts[0], parts[1]
+		} else {
+			for _, part := range parts {
+				if idx := strings.Index(part, ":"); idx > 0 {
+					branch[strings.TrimSpace(part[:idx])] = part[idx+1:]
+				}
+			}
+		}
+		if _, ok := branch["other"]; !ok {
+			return nil, "", fmt.Errorf("plural token without an other form")
+		}
+		branches[i] = branch
+	}
+	expanded := map[string]string{}
+	for _, form := range cldrForms {
+		present := false
+		for _, branch := range branches {
+			_, ok := branch[form]
+			present = present || ok
+		}
+		if !present {
+			continue
+		}
+		i := 0
+		expanded[form] = mobilePluralRegex.ReplaceAllStringFunc(text, func(string) string {
+			branch := branches[i]
+			i++
+			if text, ok := branch[form]; ok {
+				return text
+			}
+			return branch["other"]
+		})
+	}
+	return expanded, param, nil
+}
+
+// mobileParams returns the argument order of a source text: the plural param first, then params by
+// first use (forms in CLDR order).
+func mobileParams(text string, forms map[string]string, pluralParam string) ([]mobileParam, bool, error) {
+	forms, pluralParam, err := pluralForms(text, forms, pluralParam)
+	if err != nil {
+		return nil, false, err
+	}
+	var params []mobileParam
+	seen := map[string]bool{}
+	if forms != nil {
+		params = append(params, mobileParam{name: pluralParam, kind: paramCount})
+		seen[pluralParam] = true
+	}
+	var texts []string
+	if forms == nil {
+		texts = []string{text}
+	}
+	for _, form := range cldrForms {
+		if text, ok := forms[form]; ok {
+			texts = append(texts, text)
+		}
+	}
+	for _, text := range texts {
+		for _, match := range mobilePlaceholderRegex.FindAllStringSubmatch(text, -1) {
+			if !seen[match[2]] {
+				seen[match[2]] = true
+				params = append(params, mobileParam{name: match[2], kind: match[1]})
+			}
+		}
+	}
+	return params, forms != nil, nil
+}
+
+// convertMobileMessage splits a text of any language into parts using the source params. A plain
+// text becomes the other form when the source is plural.
+func convertMobileMessage(text string, forms map[string]string, srcPlural bool, params []mobileParam) (*mobileMessage, error) {
+	pluralParam := ""
+	if srcPlural {
+		pluralParam = params[0].name
+	}
+	forms, param, err := pluralForms(text, forms, pluralParam)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case forms != nil && !srcPlural:
+		return nil, fmt.Errorf("uses plural forms but the source text does not")
+	case forms != nil && param != pluralParam:
+		return nil, fmt.Errorf("plural param %q differs from the source (%q)", param, pluralParam)
+	case forms == nil && srcPlural:
+		forms = map[string]string{"other": text}
+	}
+	msg := &mobileMessage{params: params}
+	if forms == nil {
+		msg.parts, err = mobileParts(text, params)
+		return msg, err
+	}
+	if _, ok := forms["other"]; !ok {
+		return nil, fmt.Errorf("plural forms without an other form")
+	}
+	msg.forms = map[string][]mobilePart{}
+	for form, text := range forms {
+		if !isCLDRForm(form) {
+			return nil, fmt.Errorf("unknown plural form %q", form)
+		}
+		if msg.forms[form], err = mobileParts(text, params); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+// mobileParts splits text into literals and placeholders; every placeholder must be a source param.
+func mobileParts(text string, params []mobileParam) ([]mobilePart, error) {
+	var parts []mobilePart
+	last := 0
+	for _, loc := range mobilePlaceholderRegex.FindAllStringSubmatchIndex(text, -1) {
+		name := text[loc[4]:loc[5]]
+		index := 0
+		for i, param := range params {
+			if param.name == name {
+				index = i + 1
+			}
+		}
+		if index == 0 {
+			return nil, fmt.Errorf("param %q is not used by the source text", name)
+		}
+		if loc[0] > last {
+			parts = append(parts, mobilePart{text: text[last:loc[0]]})
+		}
+		parts = append(parts, mobilePart{param: index})
+		last = loc[1]
+	}
+	if last < len(text) {
+		parts = append(parts, mobilePart{text: text[last:]})
+	}
+	return parts, nil
+}
+
+func isCLDRForm(form string) bool {
+	for _, name := range cldrForms {
+		if form == name {
+			return true
+		}
+	}
+	return false
+}
+
+// renderParts renders parts with escape for literals and placeholder for params.
+func renderParts(parts []mobilePart, escape func(string) string, placeholder func(index int) string) string {
+	var b strings.Builder
+	for _, part := range parts {
+		if part.param > 0 {
+			b.WriteString(placeholder(part.param))
+		} else {
+			b.WriteString(escape(part.text))
+		}
+	}
+	return b.String()
+}
+
+// reportMobileSkips lists the entries that could not be converted.
+func reportMobileSkips(format string, skipped []mobileSkip) {
+	if len(skipped) == 0 {
+		return
+	}
+	sort.SliceStable(skipped, func(i, j int) bool { return skipped[i].lang < skipped[j].lang })
+	fmt.Fprintf(os.Stderr, "msgcat: %s: %d entry(ies) could not be converted:\n", format, len(skipped))
+	for _, skip := range skipped {
+		fmt.Fprintf(os.Stderr, "  %s %s: %s\n", skip.lang, skip.key, skip.reason)
+	}
+}
+
+// localeParts splits a language tag into language, script and region (e.g. "zh-hant-tw" -> zh, Hant, TW).
+func localeParts(lang string) (language, script, region string) {
+	parts := strings.FieldsFunc(lang, func(r rune) bool { return r == '-' || r == '_' })
+	for i, part := range parts {
+		switch {
+		case i == 0:
+			language = strings.ToLower(part)
+		case len(part) == 4 && script == "" && region == "":
+			script = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		case len(part) == 2 || len(part) == 3:
+			region = strings.ToUpper(part)
+		}
+	}
+	return language, script, region
+}
+
+// joinLocale joins the non-empty locale parts with sep.
+func joinLocale(sep string, parts ...string) string {
+	var out []string
+	for _, part := range parts {
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return strings.Join(out, sep)
+}
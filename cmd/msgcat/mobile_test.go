@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const mobileTestSource = `default:
+  short: Err
+  long: Err
+set:
+  greeting.hello:
+    short: Hello {{name}}, 100% "ok" & more
+    long: "@{{num:amount}} due on {{date:when}}\nit's late"
+  person.cats:
+    short_forms:
+      one: "{{count}} cat of {{owner}}"
+      other: "{{count}} cats of {{owner}}"
+  inbox.items:
+    short: "{{count}} {{plural:count|item|items}} in {{box}}"
+`
+
+const mobileTestTarget = `default:
+  short: Erro
+  long: Erro
+set:
+  greeting.hello:
+    short: Olá {{name}}
+  person.cats:
+    short_forms:
+      one: "{{count}} gato de {{owner}}"
+      other: "{{count}} gatos de {{owner}}"
+  inbox.items:
+    short: "{{count}} itens em {{caixa}}"
+`
+
+func exportMobileTest(t *testing.T, format string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "en.yaml"), mobileTestSource)
+	writeTestFile(t, filepath.Join(dir, "pt-br.yaml"), mobileTestTarget)
+	if err := runExport(&exportConfig{format: format, source: filepath.Join(dir, "en.yaml"), outdir: filepath.Join(dir, "out")}); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "out")
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func assertContains(t *testing.T, name, got string, wants ...string) {
+	t.Helper()
+	for _, want := range wants {
+		if !strings.Contains(got, want) {
+			t.Errorf("%s missing %q; got\n%s", name, want, got)
+		}
+	}
+}
+
+func TestExportAndroid(t *testing.T) {
+	out := exportMobileTest(t, "android")
+	assertContains(t, "values/strings.xml", readTestFile(t, filepath.Join(out, "values", "strings.xml")),
+		`<string name="greeting_hello">Hello %1$s, 100%% \"ok\" &amp; more</string>`,
+		`<string name="greeting_hello_long">\@%1$s due on %2$s\nit\'s late</string>`,
+		`<!-- person.cats (args: 1=count, 2=owner) -->`,
+		"<plurals name=\"person_cats\">\n        <item quantity=\"one\">%1$d cat of %2$s</item>\n        <item quantity=\"other\">%1$d cats of %2$s</item>\n    </plurals>",
+		`<item quantity="one">%1$d item in %2$s</item>`,
+	)
+	ptBR := readTestFile(t, filepath.Join(out, "values-pt-rBR", "strings.xml"))
+	assertContains(t, "values-pt-rBR/strings.xml", ptBR,
+		`<string name="greeting_hello">Olá %1$s</string>`,
+		`<item quantity="other">%1$d gatos de %2$s</item>`,
+	)
+	if strings.Contains(ptBR, "inbox_items") {
+		t.Errorf("inbox.items uses a param missing from the source and should be skipped:\n%s", ptBR)
+	}
+}
+
+func TestExportIOS(t *testing.T) {
+	out := exportMobileTest(t, "ios")
+	assertContains(t, "en.lproj/Localizable.strings", readTestFile(t, filepath.Join(out, "en.lproj", "Localizable.strings")),
+		`"greeting.hello" = "Hello %1$@, 100%% \"ok\" & more";`,
+		`"greeting.hello.long" = "@%1$@ due on %2$@\nit's late";`,
+	)
+	assertContains(t, "pt-BR.lproj/Localizable.stringsdict", readTestFile(t, filepath.Join(out, "pt-BR.lproj", "Localizable.stringsdict")),
+		"<key>person.cats</key>",
+		"<key>NSStringLocalizedFormatKey</key>\n\t\t<string>%1$#@count@</string>",
+		"<key>one</key>\n\t\t\t<string>%1$ld gato de %2$@</string>",
+	)
+}
+
+func TestExportARB(t *testing.T) {
+	out := exportMobileTest(t, "arb")
+	var en map[string]interface{}
+	if err := json.Unmarshal([]byte(readTestFile(t, filepath.Join(out, "app_en.arb"))), &en); err != nil {
+		t.Fatal(err)
+	}
+	wants := map[string]interface{}{
+		"@@locale":          "en",
+		"greetingHello":     `Hello {name}, 100% "ok" & more`,
+		"greetingHelloLong": "@{amount} due on {when}\nit's late",
+		"personCats":        "{count, plural, one{{count} cat of {owner}} other{{count} cats of {owner}}}",
+		"inboxItems":        "{count, plural, one{{count} item in {box}} other{{count} items in {box}}}",
+	}
+	for key, want := range wants {
+		if en[key] != want {
+			t.Errorf("%s = %q, want %q", key, en[key], want)
+		}
+	}
+	meta, _ := en["@greetingHelloLong"].(map[string]interface{})
+	placeholders, _ := meta["placeholders"].(map[string]interface{})
+	if got := placeholders["when"]; !reflect.DeepEqual(got, map[string]interface{}{"type": "DateTime", "format": "yMd"}) {
+		t.Errorf("when placeholder = %v", got)
+	}
+	ptBR := readTestFile(t, filepath.Join(out, "app_pt_BR.arb"))
+	assertContains(t, "app_pt_BR.arb", ptBR, `"@@locale": "pt_BR"`, `"greetingHello": "Olá {name}"`)
+	if strings.Contains(ptBR, `"@greetingHello"`) {
+		t.Errorf("metadata belongs in the template file only:\n%s", ptBR)
+	}
+}
+
+func TestMobileMessages_skips(t *testing.T) {
+	m, _, err := readMessagesYAML([]byte(`set:
+  a.b:
+    short: one
+  a_b:
+    short: two
+  multi:
+    short: "{{plural:a|x|y}} {{plural:b|x|y}}"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := &langFile{lang: "en", messages: m, comments: map[string]string{}}
+	byLang, skipped := mobileMessages(source, nil, androidName)
+	if len(byLang["en"]) != 1 || byLang["en"][0].key != "a.b" {
+		t.Errorf("converted = %+v", byLang["en"])
+	}
+	want := []mobileSkip{
+		{"en", "a_b", `name "a_b" already used by a.b`},
+		{"en", "multi", "plural tokens on several params (a, b)"},
+	}
+	if !reflect.DeepEqual(skipped, want) {
+		t.Errorf("skipped = %+v, want %+v", skipped, want)
+	}
+}
+
+func TestPluralForms_inline(t *testing.T) {
+	forms, param, err := pluralForms("{{plural:n|one:a {{n}}|few:some|other:many}} left, {{plural:n|x|y}}", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"one": "a {{n}} left, x", "few": "some left, y", "other": "many left, y"}
+	if param != "n" || !reflect.DeepEqual(forms, want) {
+		t.Errorf("forms = %v (param %q), want %v", forms, param, want)
+	}
+}
+
+func TestMobileNames(t *testing.T) {
+	cases := []struct {
+		key          string
+		long         bool
+		android, arb string
+	}{
+		{"greeting.hello", false, "greeting_hello", "greetingHello"},
+		{"greeting.hello", true, "greeting_hello_long", "greetingHelloLong"},
+		{"Error-Not_Found", false, "error_not_found", "errorNotFound"},
+		{"404.page", false, "msg_404_page", "msg404Page"},
+	}
+	for _, c := range cases {
+		if got := androidName(c.key, c.long); got != c.android {
+			t.Errorf("androidName(%q, %v) = %q, want %q", c.key, c.long, got, c.android)
+		}
+		if got := arbName(c.key, c.long); got != c.arb {
+			t.Errorf("arbName(%q, %v) = %q, want %q", c.key, c.long, got, c.arb)
+		}
+	}
+	locales := map[string][3]string{
+		"es":      {"values-es", "es.lproj", "es"},
+		"pt-br":   {"values-pt-rBR", "pt-BR.lproj", "pt_BR"},
+		"zh-hant": {"values-b+zh+Hant", "zh-Hant.lproj", "zh_Hant"},
+	}
+	for lang, want := range locales {
+		got := [3]string{androidValuesDir(lang), iosLprojDir(lang), arbLocale(lang)}
+		if got != want {
+			t.Errorf("locale dirs for %q = %v, want %v", lang, got, want)
+		}
+	}
+}
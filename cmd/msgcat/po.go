@@ -0,0 +1,328 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/loopcontext/msgcat"
+	"github.com/loopcontext/msgcat/internal/plural"
+)
+
+// poLongContext is appended to the key in msgctxt for long text; '#' cannot appear in message keys.
+const poLongContext = "#long"
+
+// poEntry is one gettext message. msgstr has one item, or one per plural form when msgidPlural is set.
+type poEntry struct {
+	translatorComments []string
+	extractedComments  []string
+	flags              []string
+	msgctxt            string
+	msgid              string
+	msgidPlural        string
+	msgstr             []string
+	hasContext         bool
+}
+
+func exportPO(cfg *exportConfig, source *langFile, targets []*langFile) error {
+	pot := poFile(source, nil)
+	if err := cfg.writeExport(cfg.name+".pot", pot); err != nil {
+		return err
+	}
+	for _, target := range targets {
+		if err := cfg.writeExport(target.lang+".po", poFile(source, target)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// poFile renders the entries of source with translations from target (nil for the .pot template).
+func poFile(source, target *langFile) []byte {
+	header := []string{
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding: 8bit",
+		"X-Generator: msgcat",
+		"X-Msgcat-Source-Language: " + source.lang,
+	}
+	forms := []string{"one", "other"}
+	if target == nil {
+		header = append(header, "Language: ", "Plural-Forms: nplurals=INTEGER; plural=EXPRESSION;")
+	} else {
+		forms = plural.Forms(target.lang)
+		header = append(header, "Language: "+target.lang, "Plural-Forms: "+plural.GettextPluralForms(target.lang))
+		if target.messages.Group != "" {
+			header = append(header, "X-Msgcat-Group: "+string(target.messages.Group))
+		}
+	}
+	var buf bytes.Buffer
+	writePOEntry(&buf, poEntry{msgstr: []string{strings.Join(header, "\n") + "\n"}})
+
+	for _, key := range source.sortedKeys() {
+		srcEntry := source.messages.Set[key]
+		var dstEntry msgcat.RawMessage
+		var comment string
+		if target != nil {
+			dstEntry = target.messages.Set[key]
+			comment = target.comments[key]
+		} else {
+			comment = source.comments[key]
+		}
+		var extracted []string
+		if srcEntry.Code != "" {
+			extracted = append(extracted, "code: "+string(srcEntry.Code))
+		}
+		fields := []struct {
+			context          string
+			srcText, dstText string
+			srcForms         map[string]string
+			dstForms         map[string]string
+		}{
+			{key, srcEntry.ShortTpl, dstEntry.ShortTpl, srcEntry.ShortForms, dstEntry.ShortForms},
+			{key + poLongContext, srcEntry.LongTpl, dstEntry.LongTpl, srcEntry.LongForms, dstEntry.LongForms},
+		}
+		for _, field := range fields {
+			entry := poEntry{msgctxt: field.context, hasContext: true, extractedComments: extracted}
+			if comment != "" {
+				entry.translatorComments = strings.Split(comment, "\n")
+				comment = ""
+			}
+			if len(field.srcForms) > 0 {
+				entry.msgid = firstForm(field.srcForms, "one", "other")
+				entry.msgidPlural = firstForm(field.srcForms, "other", "many", "few")
+				for _, form := range forms {
+					entry.msgstr = append(entry.msgstr, field.dstForms[form])
+				}
+			} else if field.srcText != "" {
+				entry.msgid = field.srcText
+				entry.msgstr = []string{field.dstText}
+			} else {
+				continue
+			}
+			buf.WriteString("\n")
+			writePOEntry(&buf, entry)
+		}
+	}
+	return buf.Bytes()
+}
+
+// firstForm returns the first non-empty form among names, or any form when none of them is set.
+func firstForm(forms map[string]string, names ...string) string {
+	for _, name := range names {
+		if forms[name] != "" {
+			return forms[name]
+		}
+	}
+	for _, name := range []string{"zero", "one", "two", "few", "many", "other"} {
+		if forms[name] != "" {
+			return forms[name]
+		}
+	}
+	return ""
+}
+
+func writePOEntry(buf *bytes.Buffer, entry poEntry) {
+	for _, line := range entry.translatorComments {
+		buf.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+	}
+	for _, line := range entry.extractedComments {
+		buf.WriteString("#. " + line + "\n")
+	}
+	if len(entry.flags) > 0 {
+		buf.WriteString("#, " + strings.Join(entry.flags, ", ") + "\n")
+	}
+	if entry.hasContext {
+		writePOString(buf, "msgctxt", entry.msgctxt)
+	}
+	writePOString(buf, "msgid", entry.msgid)
+	if entry.msgidPlural == "" {
+		writePOString(buf, "msgstr", entry.msgstr[0])
+		return
+	}
+	writePOString(buf, "msgid_plural", entry.msgidPlural)
+	for i, text := range entry.msgstr {
+		writePOString(buf, fmt.Sprintf("msgstr[%d]", i), text)
+	}
+}
+
+// writePOString writes a keyword and a quoted string, split after each newline as gettext tools do.
+func writePOString(buf *bytes.Buffer, keyword, text string) {
+	if !strings.Contains(strings.TrimSuffix(text, "\n"), "\n") {
+		buf.WriteString(keyword + " " + quotePO(text) + "\n")
+		return
+	}
+	buf.WriteString(keyword + " \"\"\n")
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line != "" {
+			buf.WriteString(quotePO(line) + "\n")
+		}
+	}
+}
+
+func quotePO(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// parsePO parses gettext entries; the header is the entry with an empty msgid and no context.
+// Obsolete (#~) entries and previous-string (#|) comments are ignored.
+func parsePO(data []byte) ([]poEntry, error) {
+	var entries []poEntry
+	var cur poEntry
+	var target *string
+	started := false
+	flush := func() {
+		if started {
+			entries = append(entries, cur)
+		}
+		cur, target, started = poEntry{}, nil, false
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			flush()
+			continue
+		case strings.HasPrefix(line, "#"):
+			if started && cur.msgstr != nil {
+				flush()
+			}
+			switch {
+			case strings.HasPrefix(line, "#."):
+				cur.extractedComments = append(cur.extractedComments, strings.TrimSpace(line[2:]))
+			case strings.HasPrefix(line, "#,"):
+				for _, flag := range strings.Split(line[2:], ",") {
+					cur.flags = append(cur.flags, strings.TrimSpace(flag))
+				}
+			case strings.HasPrefix(line, "#~"), strings.HasPrefix(line, "#|"), strings.HasPrefix(line, "#:"):
+			default:
+				cur.translatorComments = append(cur.translatorComments, strings.TrimPrefix(strings.TrimPrefix(line, "#"), " "))
+			}
+			continue
+		case strings.HasPrefix(line, `"`):
+			if target == nil {
+				return nil, fmt.Errorf("line %d: string without keyword", lineNo)
+			}
+			text, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNo, err)
+			}
+			*target += text
+			continue
+		}
+		keyword, rest, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("line %d: invalid line %q", lineNo, line)
+		}
+		text, err := strconv.Unquote(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNo, err)
+		}
+		if (keyword == "msgctxt" || keyword == "msgid") && cur.msgstr != nil {
+			flush()
+		}
+		started = true
+		switch {
+		case keyword == "msgctxt":
+			cur.msgctxt, cur.hasContext = text, true
+			target = &cur.msgctxt
+		case keyword == "msgid":
+			cur.msgid = text
+			target = &cur.msgid
+		case keyword == "msgid_plural":
+			cur.msgidPlural = text
+			target = &cur.msgidPlural
+		case keyword == "msgstr" || strings.HasPrefix(keyword, "msgstr["):
+			cur.msgstr = append(cur.msgstr, text)
+			target = &cur.msgstr[len(cur.msgstr)-1]
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword %q", lineNo, keyword)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return entries, nil
+}
+
+// poHeader returns a header field (e.g. "Language") from the header entry.
+func poHeader(entries []poEntry, field string) string {
+	for _, entry := range entries {
+		if entry.hasContext || entry.msgid != "" || len(entry.msgstr) == 0 {
+			continue
+		}
+		for _, line := range strings.Split(entry.msgstr[0], "\n") {
+			if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(name), field) {
+				return strings.TrimSpace(value)
+			}
+		}
+	}
+	return ""
+}
+
+func importPO(cfg *importConfig, path string) (map[string]map[string]importUpdate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := parsePO(data)
+	if err != nil {
+		return nil, err
+	}
+	lang := strings.ToLower(strings.ReplaceAll(poHeader(entries, "Language"), "_", "-"))
+	if lang == "" {
+		lang = langFromPath(path)
+	}
+	if lang == "" || strings.HasSuffix(path, ".pot") {
+		return nil, fmt.Errorf("no language (set the Language header or name the file <lang>.po)")
+	}
+	forms := plural.Forms(lang)
+	updates := map[string]importUpdate{}
+	for _, entry := range entries {
+		if !entry.hasContext || entry.msgctxt == "" || hasFlag(entry.flags, "fuzzy") {
+			continue
+		}
+		key, long := strings.CutSuffix(entry.msgctxt, poLongContext)
+		update := updates[key]
+		if len(entry.translatorComments) > 0 && update.comment == "" {
+			update.comment = strings.Join(entry.translatorComments, "\n")
+		}
+		switch {
+		case entry.msgidPlural != "":
+			byForm := map[string]string{}
+			for i, text := range entry.msgstr {
+				if i < len(forms) {
+					byForm[forms[i]] = text
+				}
+			}
+			if long {
+				update.longForms = byForm
+			} else {
+				update.shortForms = byForm
+			}
+		case len(entry.msgstr) > 0 && long:
+			update.long = entry.msgstr[0]
+		case len(entry.msgstr) > 0:
+			update.short = entry.msgstr[0]
+		}
+		updates[key] = update
+	}
+	return map[string]map[string]importUpdate{lang: updates}, nil
+}
+
+func hasFlag(flags []string, name string) bool {
+	for _, flag := range flags {
+		if flag == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExportPO(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "en.yaml"), `default:
+  short: Err
+  long: Err
+set:
+  greeting.hello:
+    code: GREETING
+    short: Hello {{name}}
+    long: "Hello,\nwelcome"
+  person.cats:
+    short_forms:
+      one: "{{count}} cat"
+      other: "{{count}} cats"
+`)
+	writeTestFile(t, filepath.Join(dir, "ru.yaml"), `default:
+  short: Ошибка
+  long: Ошибка
+set:
+  # Informal tone
+  greeting.hello:
+    short: Привет {{name}}
+`)
+	if err := runExport(&exportConfig{format: "po", source: filepath.Join(dir, "en.yaml"), name: "messages"}); err != nil {
+		t.Fatal(err)
+	}
+	pot, err := os.ReadFile(filepath.Join(dir, "messages.pot"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"#. code: GREETING\nmsgctxt \"greeting.hello\"\nmsgid \"Hello {{name}}\"\nmsgstr \"\"\n",
+		"msgctxt \"greeting.hello#long\"\nmsgid \"\"\n\"Hello,\\n\"\n\"welcome\"\n",
+		"msgid \"{{count}} cat\"\nmsgid_plural \"{{count}} cats\"\nmsgstr[0] \"\"\nmsgstr[1] \"\"\n",
+	} {
+		if !strings.Contains(string(pot), want) {
+			t.Errorf("pot missing %q; got\n%s", want, pot)
+		}
+	}
+	po, err := os.ReadFile(filepath.Join(dir, "ru.po"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"\"Language: ru\\n\"",
+		"\"Plural-Forms: nplurals=3;",
+		"# Informal tone\n#. code: GREETING\nmsgctxt \"greeting.hello\"\nmsgid \"Hello {{name}}\"\nmsgstr \"Привет {{name}}\"\n",
+		"msgstr[2] \"\"\n",
+	} {
+		if !strings.Contains(string(po), want) {
+			t.Errorf("po missing %q; got\n%s", want, po)
+		}
+	}
+}
+
+func TestImportPO(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "en.yaml"), `group: api
+default:
+  short: Err
+  long: Err
+set:
+  greeting:
+    hello:
+      code: GREETING
+      short: Hello {{name}}
+      long: Hello there
+  person:
+    cats:
+      short_forms:
+        one: "{{count}} cat"
+        other: "{{count}} cats"
+      plural_param: count
+`)
+	writeTestFile(t, filepath.Join(dir, "ru.po"), `msgid ""
+msgstr ""
+"Language: ru\n"
+
+# Informal tone
+msgctxt "greeting.hello"
+msgid "Hello {{name}}"
+msgstr "Привет {{name}}"
+
+#, fuzzy
+msgctxt "greeting.hello#long"
+msgid "Hello there"
+msgstr "Guess"
+
+msgctxt "person.cats"
+msgid "{{count}} cat"
+msgid_plural "{{count}} cats"
+msgstr[0] "{{count}} кошка"
+msgstr[1] "{{count}} кошки"
+msgstr[2] "{{count}} кошек"
+
+msgctxt "unknown.key"
+msgid "x"
+msgstr "y"
+`)
+	cfg := &importConfig{format: "po", source: filepath.Join(dir, "en.yaml"), inputs: []string{filepath.Join(dir, "ru.po")}}
+	if err := runImport(cfg); err != nil {
+		t.Fatal(err)
+	}
+	ru, err := readLangFile("ru", filepath.Join(dir, "ru.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ru.nested || ru.messages.Group != "api" {
+		t.Errorf("new file should follow source style and group; nested=%v group=%q", ru.nested, ru.messages.Group)
+	}
+	hello := ru.messages.Set["greeting.hello"]
+	if hello.ShortTpl != "Привет {{name}}" || hello.LongTpl != "" || hello.Code != "GREETING" {
+		t.Errorf("unexpected greeting.hello %+v", hello)
+	}
+	cats := ru.messages.Set["person.cats"]
+	if cats.ShortForms["few"] != "{{count}} кошки" || cats.ShortForms["many"] != "{{count}} кошек" || cats.PluralParam != "count" {
+		t.Errorf("unexpected person.cats %+v", cats)
+	}
+	if _, ok := ru.messages.Set["unknown.key"]; ok {
+		t.Error("keys missing from the source should be skipped")
+	}
+	if ru.comments["greeting.hello"] != "Informal tone" {
+		t.Errorf("translator comment not kept: %q", ru.comments["greeting.hello"])
+	}
+
+	// A second import keeps existing code and comments in the target.
+	writeTestFile(t, filepath.Join(dir, "ru.po"), "msgctxt \"greeting.hello#long\"\nmsgid \"Hello there\"\nmsgstr \"Привет всем\"\n")
+	if err := runImport(cfg); err != nil {
+		t.Fatal(err)
+	}
+	ru, err = readLangFile("ru", filepath.Join(dir, "ru.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hello = ru.messages.Set["greeting.hello"]
+	if hello.ShortTpl != "Привет {{name}}" || hello.LongTpl != "Привет всем" || ru.comments["greeting.hello"] != "Informal tone" {
+		t.Errorf("second import lost data: %+v comments=%v", hello, ru.comments)
+	}
+}
+
+func TestImportPO_untranslated(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "en.yaml"), `default:
+  short: Err
+  long: Err
+set:
+  greeting.hello:
+    short: Hello
+    long: Hello there
+  greeting.bye:
+    short: Bye
+  person.cats:
+    short_forms:
+      one: "{{count}} cat"
+      other: "{{count}} cats"
+`)
+	writeTestFile(t, filepath.Join(dir, "ru.yaml"), `default:
+  short: Ошибка
+  long: Ошибка
+set:
+  greeting.hello:
+    short: Привет
+`)
+	writeTestFile(t, filepath.Join(dir, "ru.po"), `msgid ""
+msgstr ""
+"Language: ru\n"
+
+msgctxt "greeting.hello"
+msgid "Hello"
+msgstr "Здравствуй"
+
+msgctxt "greeting.hello#long"
+msgid "Hello there"
+msgstr ""
+
+msgctxt "greeting.bye"
+msgid "Bye"
+msgstr ""
+
+msgctxt "person.cats"
+msgid "{{count}} cat"
+msgid_plural "{{count}} cats"
+msgstr[0] ""
+msgstr[1] ""
+msgstr[2] ""
+`)
+	cfg := &importConfig{format: "po", source: filepath.Join(dir, "en.yaml"), inputs: []string{filepath.Join(dir, "ru.po")}}
+	if err := runImport(cfg); err != nil {
+		t.Fatal(err)
+	}
+	ru, err := readLangFile("ru", filepath.Join(dir, "ru.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hello := ru.messages.Set["greeting.hello"]; hello.ShortTpl != "Здравствуй" || hello.LongTpl != "" {
+		t.Errorf("greeting.hello = %+v", hello)
+	}
+	for _, key := range []string{"greeting.bye", "person.cats"} {
+		if entry, ok := ru.messages.Set[key]; ok {
+			t.Errorf("untranslated %s was written: %+v", key, entry)
+		}
+	}
+}
+
+func TestParsePO_escapesAndHeader(t *testing.T) {
+	entries, err := parsePO([]byte("msgid \"\"\nmsgstr \"Language: pt_BR\\n\"\n\nmsgctxt \"k\"\nmsgid \"a \\\"b\\\"\\tc\"\nmsgstr \"\"\n\"line1\\n\"\n\"line2\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := poHeader(entries, "Language"); got != "pt_BR" {
+		t.Errorf("Language header = %q", got)
+	}
+	if len(entries) != 2 || entries[1].msgid != "a \"b\"\tc" || entries[1].msgstr[0] != "line1\nline2" {
+		t.Errorf("unexpected entries %+v", entries)
+	}
+}
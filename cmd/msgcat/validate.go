@@ -0,0 +1,147 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/loopcontext/msgcat"
+)
+
+// validateConfig holds flags for the validate command.
+type validateConfig struct {
+	source      string
+	sourceLang  string
+	targetLangs string
+	targetDir   string
+}
+
+// lengthViolation is a short text longer than its entry's max_length.
+type lengthViolation struct {
+	key       string
+	field     string // short, short_forms.<form> or "short (<form>)"
+	length    int
+	maxLength int
+	text      string
+}
+
+func usageValidate() {
+	fmt.Fprint(os.Stderr, `usage: msgcat validate -source <file> [options]
+
+Validate checks the source message file and its translations. Each short text (and short_forms
+form) must fit the entry's max_length, taken from the language's entry or else from the source
+entry. Length is counted in characters, without {{...}} placeholders; inline plural tokens are
+checked per form. Violations are listed per language and the command exits with status 1.
+
+Flags:
+`)
+	flag.CommandLine.PrintDefaults()
+}
+
+func parseValidateFlags(args []string) (*validateConfig, error) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.Usage = usageValidate
+	var cfg validateConfig
+	fs.StringVar(&cfg.source, "source", "", "Source message file (e.g. resources/messages/en.yaml). Required.")
+	fs.StringVar(&cfg.sourceLang, "sourceLang", "", "Source language (default: inferred from the source file name).")
+	fs.StringVar(&cfg.targetLangs, "targetLangs", "", "Comma-separated target language tags (default: the *.yaml files in -targetDir).")
+	fs.StringVar(&cfg.targetDir, "targetDir", "", "Directory containing target <lang>.yaml files (default: same dir as source).")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func runValidate(cfg *validateConfig) error {
+	if cfg.source == "" {
+		return fmt.Errorf("validate: -source is required")
+	}
+	if cfg.sourceLang == "" {
+		cfg.sourceLang = langFromPath(cfg.source)
+	}
+	source, err := readLangFile(cfg.sourceLang, cfg.source)
+	if err != nil {
+		return fmt.Errorf("read source: %w", err)
+	}
+	targets, err := readTargetFiles(source, cfg.targetLangs, cfg.targetDir)
+	if err != nil {
+		return err
+	}
+	total := 0
+	for _, file := range append([]*langFile{source}, targets...) {
+		violations := checkMaxLength(source, file)
+		printLengthViolations(os.Stdout, file, violations)
+		total += len(violations)
+	}
+	if total > 0 {
+		return fmt.Errorf("validate: %d text(s) exceed max_length", total)
+	}
+	fmt.Fprintf(os.Stderr, "msgcat: %d file(s) valid\n", len(targets)+1)
+	return nil
+}
+
+// checkMaxLength returns the short texts of file longer than their max_length, in key order.
+func checkMaxLength(source, file *langFile) []lengthViolation {
+	var violations []lengthViolation
+	for _, key := range file.sortedKeys() {
+		entry := file.messages.Set[key]
+		maxLength := entry.MaxLength
+		if maxLength == 0 {
+			maxLength = source.messages.Set[key].MaxLength
+		}
+		if maxLength <= 0 {
+			continue
+		}
+		for _, text := range shortTexts(entry) {
+			if length := textLength(text.text); length > maxLength {
+				violations = append(violations, lengthViolation{key: key, field: text.field, length: length, maxLength: maxLength, text: text.text})
+			}
+		}
+	}
+	return violations
+}
+
+// shortText is one checked text of an entry.
+type shortText struct {
+	field string
+	text  string
+}
+
+// shortTexts returns the short text of an entry, or one text per form in CLDR order: short_forms.<form>,
+// or "short (<form>)" for inline plural tokens.
+func shortTexts(entry msgcat.RawMessage) []shortText {
+	forms, _, err := pluralForms(entry.ShortTpl, entry.ShortForms, entry.PluralParam)
+	if err != nil || forms == nil {
+		return []shortText{{"short", entry.ShortTpl}}
+	}
+	var texts []shortText
+	for _, form := range cldrForms {
+		text, ok := forms[form]
+		if !ok {
+			continue
+		}
+		field := "short (" + form + ")"
+		if len(entry.ShortForms) > 0 {
+			field = "short_forms." + form
+		}
+		texts = append(texts, shortText{field, text})
+	}
+	return texts
+}
+
+// textLength counts the characters of a template without its placeholders.
+func textLength(text string) int {
+	return utf8.RuneCountInString(mobilePlaceholderRegex.ReplaceAllString(text, ""))
+}
+
+func printLengthViolations(w io.Writer, file *langFile, violations []lengthViolation) {
+	if len(violations) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%s (%s): %d text(s) exceed max_length\n", file.path, file.lang, len(violations))
+	for _, v := range violations {
+		fmt.Fprintf(w, "  %s %s: %d > %d characters: %q\n", v.key, v.field, v.length, v.maxLength, v.text)
+	}
+}
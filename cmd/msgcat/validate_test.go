@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheckMaxLength(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "en.yaml"), `default:
+  short: Err
+  long: Err
+set:
+  checkout.pay:
+    short: Pay {{amount}}
+    long: A long explanation that is not limited
+    max_length: 10
+  person.cats:
+    short_forms:
+      one: "{{count}} cat"
+      other: "{{count}} cats"
+    max_length: 7
+  inbox:
+    short: "{{n}} {{plural:n|message|messages}}"
+    max_length: 9
+  free.text:
+    short: No limit on this one at all
+`)
+	writeTestFile(t, filepath.Join(dir, "es.yaml"), `default:
+  short: Error
+  long: Error
+set:
+  checkout.pay:
+    short: Pagar ahora {{amount}}
+  person.cats:
+    short_forms:
+      one: "{{count}} gato"
+      other: "{{count}} gatitos"
+  inbox:
+    short: "{{n}} {{plural:n|mensajito|mensajitos}}"
+  free.text:
+    short: Sin límite
+    max_length: 5
+`)
+	source, err := readLangFile("en", filepath.Join(dir, "en.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	es, err := readLangFile("es", filepath.Join(dir, "es.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := checkMaxLength(source, source); len(got) != 0 {
+		t.Errorf("source violations = %+v", got)
+	}
+	got := checkMaxLength(source, es)
+	want := []lengthViolation{
+		{key: "checkout.pay", field: "short", length: 12, maxLength: 10, text: "Pagar ahora {{amount}}"},
+		{key: "free.text", field: "short", length: 10, maxLength: 5, text: "Sin límite"},
+		{key: "inbox", field: "short (one)", length: 10, maxLength: 9, text: "{{n}} mensajito"},
+		{key: "inbox", field: "short (other)", length: 11, maxLength: 9, text: "{{n}} mensajitos"},
+		{key: "person.cats", field: "short_forms.other", length: 8, maxLength: 7, text: "{{count}} gatitos"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("violations =\n%+v\nwant\n%+v", got, want)
+	}
+
+	var buf bytes.Buffer
+	printLengthViolations(&buf, es, got[:1])
+	if !strings.Contains(buf.String(), `(es): 1 text(s) exceed max_length`) || !strings.Contains(buf.String(), `checkout.pay short: 12 > 10 characters`) {
+		t.Errorf("report = %s", buf.String())
+	}
+
+	err = runValidate(&validateConfig{source: filepath.Join(dir, "en.yaml")})
+	if err == nil || !strings.Contains(err.Error(), "5 text(s) exceed max_length") {
+		t.Errorf("runValidate err = %v", err)
+	}
+}
@@ -0,0 +1,491 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/loopcontext/msgcat"
+	"github.com/loopcontext/msgcat/internal/plural"
+)
+
+// Translation states kept in RawMessage.State.
+const (
+	stateNew        = "new"
+	stateTranslated = "translated"
+	stateReviewed   = "reviewed"
+)
+
+// xliffPlaceholderRegex matches the tokens protected as inline <ph> elements: {{name}}, {{num:x}} and
+// {{date:x}}. Plural tokens stay as text since their branches are translatable.
+var xliffPlaceholderRegex = regexp.MustCompile(`\{\{(?:num:|date:)?[a-zA-Z_][a-zA-Z0-9_.]*\}\}`)
+
+// xliff12States and xliff20States map RawMessage.State to XLIFF 1.2 target and 2.0 segment states; xliffImportStates maps back.
+var (
+	xliff12States = map[string]string{stateNew: "new", stateTranslated: "translated", stateReviewed: "signed-off"}
+	xliff20States = map[string]string{stateNew: "initial", stateTranslated: "translated", stateReviewed: "reviewed"}
+
+	xliffImportStates = map[string]string{
+		"new": stateNew, "needs-translation": stateNew, "needs-l10n": stateNew, "needs-adaptation": stateNew, "initial": stateNew,
+		"translated": stateTranslated, "needs-review-translation": stateTranslated, "needs-review-l10n": stateTranslated,
+		"needs-review-adaptation": stateTranslated,
+		"signed-off":              stateReviewed, "final": stateReviewed, "reviewed": stateReviewed,
+	}
+)
+
+// xliffSegment is one translatable text of an entry: "short", "long", or "short_forms.<form>" /
+// "long_forms.<form>".
+type xliffSegment struct {
+	id     string
+	source string
+	target string
+}
+
+// xliffSegments lists the segments of a key. Form segments follow the target language's forms, taking
+// the source text of the same form or "other".
+func xliffSegments(src, dst msgcat.RawMessage, targetLang string) []xliffSegment {
+	var segments []xliffSegment
+	add := func(name, srcText, dstText string, srcForms, dstForms map[string]string) {
+		if len(srcForms) == 0 {
+			if srcText != "" {
+				segments = append(segments, xliffSegment{id: name, source: srcText, target: dstText})
+			}
+			return
+		}
+		for _, form := range plural.Forms(targetLang) {
+			text := srcForms[form]
+			if text == "" {
+				text = firstForm(srcForms, "other")
+			}
+			segments = append(segments, xliffSegment{id: name + "_forms." + form, source: text, target: dstForms[form]})
+		}
+	}
+	add("short", src.ShortTpl, dst.ShortTpl, src.ShortForms, dst.ShortForms)
+	add("long", src.LongTpl, dst.LongTpl, src.LongForms, dst.LongForms)
+	return segments
+}
+
+// entryState is the state exported for a key: new when nothing is translated, else the stored state
+// (translated when unset).
+func entryState(dst msgcat.RawMessage, segments []xliffSegment) string {
+	translated := false
+	for _, segment := range segments {
+		translated = translated || segment.target != ""
+	}
+	switch {
+	case !translated:
+		return stateNew
+	case dst.State == stateReviewed:
+		return stateReviewed
+	default:
+		return stateTranslated
+	}
+}
+
+func exportXLIFF(cfg *exportConfig, source *langFile, targets []*langFile) error {
+	if cfg.xliffVersion != "1.2" && cfg.xliffVersion != "2.0" {
+		return fmt.Errorf("export: -xliffVersion must be 1.2 or 2.0, got %q", cfg.xliffVersion)
+	}
+	for _, target := range targets {
+		var buf bytes.Buffer
+		buf.WriteString(xml.Header)
+		if cfg.xliffVersion == "1.2" {
+			writeXLIFF12(&buf, source, target)
+		} else {
+			writeXLIFF20(&buf, source, target)
+		}
+		if err := cfg.writeExport(cfg.name+"."+target.lang+".xlf", buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeXLIFF12(buf *bytes.Buffer, source, target *langFile) {
+	fmt.Fprintf(buf, "<xliff version=\"1.2\" xmlns=\"urn:oasis:names:tc:xliff:document:1.2\">\n")
+	fmt.Fprintf(buf, "  <file original=\"%s\" source-language=\"%s\" target-language=\"%s\" datatype=\"plaintext\">\n    <body>\n",
+		xmlAttr(filepath.Base(source.path)), xmlAttr(source.lang), xmlAttr(target.lang))
+	for _, key := range source.sortedKeys() {
+		dst := target.messages.Set[key]
+		segments := xliffSegments(source.messages.Set[key], dst, target.lang)
+		if len(segments) == 0 {
+			continue
+		}
+		ph := newXLIFFPlaceholders()
+		var full, seg, trg strings.Builder
+		for i, segment := range segments {
+			src, dst := ph.segment(segment.source, segment.target, ph12)
+			if i > 0 {
+				full.WriteString("\n")
+			}
+			full.WriteString(src)
+			fmt.Fprintf(&seg, `<mrk mtype="seg" mid="%s">%s</mrk>`, xmlAttr(segment.id), src)
+			fmt.Fprintf(&trg, `<mrk mtype="seg" mid="%s">%s</mrk>`, xmlAttr(segment.id), dst)
+		}
+		fmt.Fprintf(buf, "      <trans-unit id=\"%s\" resname=\"%s\">\n", xmlAttr(key), xmlAttr(key))
+		fmt.Fprintf(buf, "        <source>%s</source>\n", full.String())
+		fmt.Fprintf(buf, "        <seg-source>%s</seg-source>\n", seg.String())
+		fmt.Fprintf(buf, "        <target state=\"%s\">%s</target>\n", xliff12States[entryState(dst, segments)], trg.String())
+		if code := source.messages.Set[key].Code; code != "" {
+			fmt.Fprintf(buf, "        <note from=\"msgcat\">code: %s</note>\n", xmlText(string(code)))
+		}
+		if comment := target.comments[key]; comment != "" {
+			fmt.Fprintf(buf, "        <note from=\"translator\">%s</note>\n", xmlText(comment))
+		}
+		buf.WriteString("      </trans-unit>\n")
+	}
+	buf.WriteString("    </body>\n  </file>\n</xliff>\n")
+}
+
+func writeXLIFF20(buf *bytes.Buffer, source, target *langFile) {
+	fmt.Fprintf(buf, "<xliff xmlns=\"urn:oasis:names:tc:xliff:document:2.0\" version=\"2.0\" srcLang=\"%s\" trgLang=\"%s\">\n",
+		xmlAttr(source.lang), xmlAttr(target.lang))
+	fmt.Fprintf(buf, "  <file id=\"f1\" original=\"%s\">\n", xmlAttr(filepath.Base(source.path)))
+	for _, key := range source.sortedKeys() {
+		dst := target.messages.Set[key]
+		segments := xliffSegments(source.messages.Set[key], dst, target.lang)
+		if len(segments) == 0 {
+			continue
+		}
+		state := xliff20States[entryState(dst, segments)]
+		ph := newXLIFFPlaceholders()
+		var body strings.Builder
+		for _, segment := range segments {
+			segmentState := state
+			if segment.target == "" {
+				segmentState = xliff20States[stateNew]
+			}
+			src, dst := ph.segment(segment.source, segment.target, ph20)
+			fmt.Fprintf(&body, "      <segment id=\"%s\" state=\"%s\">\n", xmlAttr(segment.id), segmentState)
+			fmt.Fprintf(&body, "        <source>%s</source>\n", src)
+			if segment.target != "" {
+				fmt.Fprintf(&body, "        <target>%s</target>\n", dst)
+			}
+			body.WriteString("      </segment>\n")
+		}
+		fmt.Fprintf(buf, "    <unit id=\"%s\" name=\"%s\">\n", xmlAttr(key), xmlAttr(key))
+		code := source.messages.Set[key].Code
+		if comment := target.comments[key]; code != "" || comment != "" {
+			buf.WriteString("      <notes>\n")
+			if code != "" {
+				fmt.Fprintf(buf, "        <note category=\"code\">%s</note>\n", xmlText(string(code)))
+			}
+			if comment != "" {
+				fmt.Fprintf(buf, "        <note category=\"translator\">%s</note>\n", xmlText(comment))
+			}
+			buf.WriteString("      </notes>\n")
+		}
+		if len(ph.tokens) > 0 {
+			buf.WriteString("      <originalData>\n")
+			for i, token := range ph.tokens {
+				fmt.Fprintf(buf, "        <data id=\"d%d\">%s</data>\n", i+1, xmlText(token))
+			}
+			buf.WriteString("      </originalData>\n")
+		}
+		buf.WriteString(body.String())
+		buf.WriteString("    </unit>\n")
+	}
+	buf.WriteString("  </file>\n</xliff>\n")
+}
+
+// xliffPlaceholders numbers the placeholders of one unit: every source occurrence gets its own <ph>
+// id, target occurrences reuse the id of the matching source occurrence, and distinct tokens get one
+// originalData entry (XLIFF 2.0).
+type xliffPlaceholders struct {
+	lastID      int
+	dataIDs     map[string]int
+	tokens      []string
+	occurrences map[string][]int // current segment: token -> source ph ids
+}
+
+func newXLIFFPlaceholders() *xliffPlaceholders {
+	return &xliffPlaceholders{dataIDs: map[string]int{}}
+}
+
+// segment renders the source and target of one segment with the given <ph> format.
+func (p *xliffPlaceholders) segment(source, target string, ph func(id, dataID int, token string) string) (string, string) {
+	p.occurrences = map[string][]int{}
+	src := p.inline(source, func(token string) int {
+		p.lastID++
+		p.occurrences[token] = append(p.occurrences[token], p.lastID)
+		return p.lastID
+	}, ph)
+	seen := map[string]int{}
+	trg := p.inline(target, func(token string) int {
+		k := seen[token]
+		seen[token]++
+		if k < len(p.occurrences[token]) {
+			return p.occurrences[token][k]
+		}
+		p.lastID++
+		return p.lastID
+	}, ph)
+	return src, trg
+}
+
+func (p *xliffPlaceholders) dataID(token string) int {
+	if id, ok := p.dataIDs[token]; ok {
+		return id
+	}
+	p.tokens = append(p.tokens, token)
+	p.dataIDs[token] = len(p.tokens)
+	return len(p.tokens)
+}
+
+// ph12 renders <ph id="n">{{name}}</ph> (XLIFF 1.2); ph20 renders <ph id="n" dataRef="dn"/> (XLIFF 2.0).
+func ph12(id, _ int, token string) string {
+	return fmt.Sprintf(`<ph id="%d">%s</ph>`, id, xmlText(token))
+}
+
+func ph20(id, dataID int, token string) string {
+	return fmt.Sprintf(`<ph id="%d" dataRef="d%d" equiv="%s"/>`, id, dataID, xmlAttr(token))
+}
+
+// inline escapes text and replaces placeholders using ph with the id chosen by nextID.
+func (p *xliffPlaceholders) inline(text string, nextID func(token string) int, ph func(id, dataID int, token string) string) string {
+	var out strings.Builder
+	last := 0
+	for _, loc := range xliffPlaceholderRegex.FindAllStringIndex(text, -1) {
+		out.WriteString(xmlText(text[last:loc[0]]))
+		token := text[loc[0]:loc[1]]
+		out.WriteString(ph(nextID(token), p.dataID(token), token))
+		last = loc[1]
+	}
+	out.WriteString(xmlText(text[last:]))
+	return out.String()
+}
+
+func xmlText(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	// Keep newlines literal; EscapeText encodes them as &#xA;.
+	return strings.ReplaceAll(buf.String(), "&#xA;", "\n")
+}
+
+func xmlAttr(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// xliffUnit is an imported trans-unit (1.2) or unit (2.0).
+type xliffUnit struct {
+	id       string
+	state    string            // 1.2 target state
+	segments map[string]string // segment id -> target text
+	states   map[string]string // 2.0 segment id -> state
+	data     map[string]string // 2.0 originalData
+}
+
+func importXLIFF(cfg *importConfig, path string) (map[string]map[string]importUpdate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	dec := xml.NewDecoder(f)
+	lang := ""
+	updates := map[string]importUpdate{}
+	var unit *xliffUnit
+	segmentID := ""
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "file":
+				if l := xmlAttrValue(t, "target-language"); l != "" {
+					lang = l
+				}
+			case "xliff":
+				if l := xmlAttrValue(t, "trgLang"); l != "" {
+					lang = l
+				}
+			case "trans-unit", "unit":
+				unit = &xliffUnit{id: xmlAttrValue(t, "id"), segments: map[string]string{}, states: map[string]string{}, data: map[string]string{}}
+			case "data":
+				text, err := xliffInline(dec, unit)
+				if err != nil {
+					return nil, err
+				}
+				if unit != nil {
+					unit.data[xmlAttrValue(t, "id")] = text
+				}
+			case "segment":
+				segmentID = xmlAttrValue(t, "id")
+				if unit != nil {
+					unit.states[segmentID] = xmlAttrValue(t, "state")
+				}
+			case "target":
+				if unit == nil {
+					continue
+				}
+				if segmentID != "" {
+					text, err := xliffInline(dec, unit)
+					if err != nil {
+						return nil, err
+					}
+					unit.segments[segmentID] = text
+					continue
+				}
+				unit.state = xmlAttrValue(t, "state")
+				if err := readXLIFF12Target(dec, unit); err != nil {
+					return nil, err
+				}
+			case "source", "seg-source", "notes", "note":
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "segment":
+				segmentID = ""
+			case "trans-unit", "unit":
+				if unit != nil && unit.id != "" {
+					if update, ok := unit.update(); ok {
+						updates[unit.id] = update
+					}
+				}
+				unit = nil
+			}
+		}
+	}
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	if lang == "" {
+		lang = langFromPath(path)
+	}
+	return map[string]map[string]importUpdate{lang: updates}, nil
+}
+
+// readXLIFF12Target reads a 1.2 <target>: one <mrk mtype="seg"> per segment, or plain content for short.
+func readXLIFF12Target(dec *xml.Decoder, unit *xliffUnit) error {
+	var plain strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "mrk" && xmlAttrValue(t, "mid") != "" {
+				text, err := xliffInline(dec, unit)
+				if err != nil {
+					return err
+				}
+				unit.segments[xmlAttrValue(t, "mid")] = text
+				continue
+			}
+			text, err := xliffInlineElement(dec, t, unit)
+			if err != nil {
+				return err
+			}
+			plain.WriteString(text)
+		case xml.CharData:
+			plain.Write(t)
+		case xml.EndElement:
+			if len(unit.segments) == 0 && strings.TrimSpace(plain.String()) != "" {
+				unit.segments["short"] = plain.String()
+			}
+			return nil
+		}
+	}
+}
+
+// xliffInline reads mixed content up to the end of the current element, restoring placeholders.
+func xliffInline(dec *xml.Decoder, unit *xliffUnit) (string, error) {
+	var out strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			out.Write(t)
+		case xml.StartElement:
+			text, err := xliffInlineElement(dec, t, unit)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(text)
+		case xml.EndElement:
+			return out.String(), nil
+		}
+	}
+}
+
+// xliffInlineElement returns the text of an inline element: the placeholder for <ph> (1.2 content,
+// 2.0 dataRef or equiv), the content for anything else (e.g. <g>, <pc>, <mrk>).
+func xliffInlineElement(dec *xml.Decoder, t xml.StartElement, unit *xliffUnit) (string, error) {
+	content, err := xliffInline(dec, unit)
+	if err != nil || t.Name.Local != "ph" {
+		return content, err
+	}
+	if ref := xmlAttrValue(t, "dataRef"); ref != "" && unit != nil {
+		if data, ok := unit.data[ref]; ok {
+			return data, nil
+		}
+	}
+	if equiv := xmlAttrValue(t, "equiv"); equiv != "" {
+		return equiv, nil
+	}
+	return content, nil
+}
+
+// update converts the unit's translated segments; segments in a new state are skipped.
+func (u *xliffUnit) update() (importUpdate, bool) {
+	var update importUpdate
+	found := false
+	state := stateReviewed
+	for id, text := range u.segments {
+		segmentState := xliffImportStates[u.state]
+		if s, ok := u.states[id]; ok {
+			segmentState = xliffImportStates[s]
+		}
+		if text == "" || segmentState == stateNew {
+			continue
+		}
+		if segmentState != stateReviewed {
+			state = stateTranslated
+		}
+		name, form, isForm := strings.Cut(id, "_forms.")
+		switch {
+		case isForm && name == "short":
+			update.shortForms = mergeForms(update.shortForms, map[string]string{form: text})
+		case isForm && name == "long":
+			update.longForms = mergeForms(update.longForms, map[string]string{form: text})
+		case id == "short":
+			update.short = text
+		case id == "long":
+			update.long = text
+		default:
+			continue
+		}
+		found = true
+	}
+	if found {
+		update.state = state
+	}
+	return update, found
+}
+
+func xmlAttrValue(t xml.StartElement, name string) string {
+	for _, attr := range t.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const xliffTestSource = `default:
+  short: Err
+  long: Err
+set:
+  greeting.hello:
+    code: GREETING
+    short: Hello {{name}}, {{name}}!
+    long: Total {{num:amount}} & more
+  person.cats:
+    short_forms:
+      one: "{{count}} cat"
+      other: "{{count}} cats"
+`
+
+func TestXLIFF_roundTrip(t *testing.T) {
+	for _, version := range []string{"1.2", "2.0"} {
+		t.Run(version, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestFile(t, filepath.Join(dir, "en.yaml"), xliffTestSource)
+			writeTestFile(t, filepath.Join(dir, "es.yaml"), `default:
+  short: Error
+  long: Error
+set:
+  greeting.hello:
+    short: Hola {{name}}, {{name}}!
+    state: reviewed
+`)
+			cfg := &exportConfig{format: "xliff", source: filepath.Join(dir, "en.yaml"), name: "messages", xliffVersion: version}
+			if err := runExport(cfg); err != nil {
+				t.Fatal(err)
+			}
+			xlfPath := filepath.Join(dir, "messages.es.xlf")
+			out, err := os.ReadFile(xlfPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			xlf := string(out)
+			wantPh := `<ph id="1">{{name}}</ph>, <ph id="2">{{name}}</ph>`
+			wantState := `state="signed-off"`
+			if version == "2.0" {
+				wantPh = `<ph id="1" dataRef="d1" equiv="{{name}}"/>, <ph id="2" dataRef="d1" equiv="{{name}}"/>`
+				wantState = `<segment id="short" state="reviewed">`
+			}
+			for _, want := range []string{wantPh, wantState, "&amp; more", "short_forms.one"} {
+				if !strings.Contains(xlf, want) {
+					t.Errorf("xliff missing %q; got\n%s", want, xlf)
+				}
+			}
+
+			// Translator fills the long text and the plural forms.
+			xlf = strings.Replace(xlf, "Hola", "Buenas", 2)
+			if version == "1.2" {
+				xlf = strings.Replace(xlf, `state="signed-off"`, `state="translated"`, 1)
+				xlf = strings.Replace(xlf, `<mrk mtype="seg" mid="long"></mrk></target>`,
+					`<mrk mtype="seg" mid="long">Total <ph id="3">{{num:amount}}</ph> &amp; más</mrk></target>`, 1)
+				xlf = strings.Replace(xlf, `<target state="new"><mrk mtype="seg" mid="short_forms.one"></mrk>`,
+					`<target state="translated"><mrk mtype="seg" mid="short_forms.one"><ph id="1">{{count}}</ph> gato</mrk>`, 1)
+			} else {
+				xlf = strings.Replace(xlf, `<segment id="short" state="reviewed">`, `<segment id="short" state="translated">`, 1)
+				xlf = strings.Replace(xlf, `<segment id="long" state="initial">
+        <source>Total <ph id="3" dataRef="d2" equiv="{{num:amount}}"/> &amp; more</source>`, `<segment id="long" state="translated">
+        <source>Total <ph id="3" dataRef="d2" equiv="{{num:amount}}"/> &amp; more</source>
+        <target>Total <ph id="3" dataRef="d2"/> &amp; más</target>`, 1)
+				xlf = strings.Replace(xlf, `<segment id="short_forms.one" state="initial">
+        <source><ph id="1" dataRef="d1" equiv="{{count}}"/> cat</source>`, `<segment id="short_forms.one" state="initial">
+        <source><ph id="1" dataRef="d1" equiv="{{count}}"/> cat</source>
+        <target><ph id="1" dataRef="d1"/> gato</target>`, 1)
+			}
+			writeTestFile(t, xlfPath, xlf)
+
+			if err := runImport(&importConfig{format: "xliff", source: filepath.Join(dir, "en.yaml"), inputs: []string{xlfPath}}); err != nil {
+				t.Fatal(err)
+			}
+			es, err := readLangFile("es", filepath.Join(dir, "es.yaml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			hello := es.messages.Set["greeting.hello"]
+			if hello.ShortTpl != "Buenas {{name}}, {{name}}!" || hello.LongTpl != "Total {{num:amount}} & más" {
+				t.Errorf("unexpected greeting.hello %+v", hello)
+			}
+			if hello.State != stateTranslated {
+				t.Errorf("state = %q, want translated", hello.State)
+			}
+			cats := es.messages.Set["person.cats"]
+			if version == "1.2" && cats.ShortForms["one"] != "{{count}} gato" {
+				t.Errorf("unexpected person.cats %+v", cats)
+			}
+			if version == "2.0" && len(cats.ShortForms) != 0 {
+				t.Errorf("segments in initial state should be skipped; got %+v", cats)
+			}
+		})
+	}
+}
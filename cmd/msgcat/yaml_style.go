@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/loopcontext/msgcat"
+	"gopkg.in/yaml.v2"
+)
+
+// readMessagesYAML parses a message file written with flat (greeting.hello:) or nested
+// (greeting: {hello: ...}) keys and reports whether it is nested, so output keeps the same style.
+func readMessagesYAML(data []byte) (msgcat.Messages, bool, error) {
+	var m msgcat.Messages
+	nested, err := msgcat.UnmarshalNestedMessages(data, &m)
+	if err == nil {
+		return m, nested, nil
+	}
+	// Not valid as nested (e.g. an entry with only code); fall back to the flat schema.
+	m = msgcat.Messages{}
+	if flatErr := yaml.Unmarshal(data, &m); flatErr != nil {
+		return m, false, flatErr
+	}
+	return m, false, nil
+}
+
+// marshalMessagesYAML writes m with nested keys when nested is set, flat keys otherwise.
+func marshalMessagesYAML(m *msgcat.Messages, nested bool) ([]byte, error) {
+	if nested {
+		return msgcat.MarshalNestedMessages(m)
+	}
+	return yaml.Marshal(m)
+}
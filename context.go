@@ -0,0 +1,49 @@
+package msgcat
+
+import "context"
+
+// languageCtxKey is the unexported context key used by WithLanguage and WithLanguages, so values set
+// by this package cannot collide with other packages (and go vet does not flag string keys).
+type languageCtxKey struct{}
+
+// WithLanguage returns a copy of ctx carrying lang for catalog lookups. It takes precedence over
+// Config.CtxLanguageKey, so it works regardless of how the catalog is configured.
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return WithLanguages(ctx, lang)
+}
+
+// WithLanguages returns a copy of ctx carrying a language preference list (e.g. parsed from
+// Accept-Language). The catalog uses the first language in the list that is loaded, then its usual
+// fallback chain. Empty tags are dropped.
+func WithLanguages(ctx context.Context, langs ...string) context.Context {
+	normalized := make([]string, 0, len(langs))
+	for _, lang := range langs {
+		if lang = normalizeLangTag(lang); lang != "" {
+			normalized = append(normalized, lang)
+		}
+	}
+	return context.WithValue(ctx, languageCtxKey{}, normalized)
+}
+
+// LanguageFromContext returns the first language set with WithLanguage or WithLanguages.
+// ok is false when none was set.
+func LanguageFromContext(ctx context.Context) (lang string, ok bool) {
+	langs, ok := LanguagesFromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return langs[0], true
+}
+
+// LanguagesFromContext returns a copy of the language preference list set with WithLanguage or
+// WithLanguages. ok is false when none was set.
+func LanguagesFromContext(ctx context.Context) (langs []string, ok bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	stored, _ := ctx.Value(languageCtxKey{}).([]string)
+	if len(stored) == 0 {
+		return nil, false
+	}
+	return append([]string(nil), stored...), true
+}
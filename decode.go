@@ -0,0 +1,205 @@
+package msgcat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// catalogDecoders maps supported catalog file extensions to a conversion into YAML, so every format
+// shares the Messages schema (default, set, group, short/long, *_forms, plural_param, code, status).
+var catalogDecoders = map[string]func(data []byte) ([]byte, error){
+	".yaml": yamlToYAML,
+	".yml":  yamlToYAML,
+	".json": jsonToYAML,
+	".toml": tomlToYAML,
+}
+
+// catalogFileLang returns the language for a catalog file name and whether the extension is supported.
+// main is true for "<lang>.<ext>" (e.g. "es.json"). With splitFiles, "<lang>.<name>.<ext>" (e.g.
+// "es.billing.yaml") is an additional file for the same language. Otherwise, or when the name does not
+// start with a language tag (e.g. "translate.es.yaml" written by msgcat merge), a dotted name is a main
+// file for its whole base name.
+func catalogFileLang(fileName string, splitFiles bool) (lang string, main bool, supported bool) {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if _, ok := catalogDecoders[ext]; !ok {
+		return "", false, false
+	}
+	base := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	if dot := strings.Index(base, "."); splitFiles && dot >= 0 && isLangTag(base[:dot]) {
+		return normalizeLangTag(base[:dot]), false, true
+	}
+	return normalizeLangTag(base), true, true
+}
+
+// isLangTag reports whether name looks like a language tag: a 2-3 letter language followed by
+// alphanumeric subtags of up to 8 characters (e.g. "en", "pt-br", "zh_Hant").
+func isLangTag(name string) bool {
+	for i, sub := range strings.Split(normalizeLangTag(name), "-") {
+		if i == 0 && (len(sub) < 2 || len(sub) > 3) || len(sub) < 1 || len(sub) > 8 {
+			return false
+		}
+		for _, r := range sub {
+			if (r < 'a' || r > 'z') && (i == 0 || r < '0' || r > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// catalogFile is one message file for a language; path is relative to ResourcePath.
+type catalogFile struct {
+	lang string
+	path string
+	main bool
+}
+
+// listCatalogFiles finds the message files in resourcePath: "<lang>.<ext>" and, with splitFiles,
+// "<lang>.<name>.<ext>" and any supported file in a "<lang>/" directory. Directories are skipped
+// without splitFiles or when not named like a language tag. Files are returned in directory order.
+func listCatalogFiles(resourcePath string, splitFiles bool) ([]catalogFile, error) {
+	entries, err := os.ReadDir(resourcePath)
+	if err != nil {
+		return nil, err
+	}
+	var files []catalogFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() {
+			if lang, main, supported := catalogFileLang(name, splitFiles); supported {
+				files = append(files, catalogFile{lang: lang, path: name, main: main})
+			}
+			continue
+		}
+		if !splitFiles || !isLangTag(name) {
+			continue
+		}
+		dirEntries, err := os.ReadDir(filepath.Join(resourcePath, name))
+		if err != nil {
+			return nil, err
+		}
+		for _, dirEntry := range dirEntries {
+			if _, ok := catalogDecoders[strings.ToLower(filepath.Ext(dirEntry.Name()))]; ok && !dirEntry.IsDir() {
+				files = append(files, catalogFile{lang: normalizeLangTag(name), path: filepath.Join(name, dirEntry.Name())})
+			}
+		}
+	}
+	return files, nil
+}
+
+// catalogMerge merges the files of one language, remembering which file defined each key so
+// duplicates can be reported with both paths.
+type catalogMerge struct {
+	messages    Messages
+	mainPath    string
+	defaultPath string
+	keyPaths    map[string]string
+}
+
+func (cm *catalogMerge) add(file catalogFile, messages Messages) error {
+	if file.main {
+		if cm.mainPath != "" {
+			return fmt.Errorf("duplicate message files for language %s: %s and %s", file.lang, cm.mainPath, file.path)
+		}
+		cm.mainPath = file.path
+	}
+	if messages.Default.ShortTpl != "" || messages.Default.LongTpl != "" {
+		if cm.defaultPath != "" {
+			return fmt.Errorf("duplicate default message for language %s in %s and %s", file.lang, cm.defaultPath, file.path)
+		}
+		cm.defaultPath = file.path
+		cm.messages.Default = messages.Default
+	}
+	if cm.messages.Group == "" {
+		cm.messages.Group = messages.Group
+	}
+	if cm.messages.Set == nil {
+		cm.messages.Set = map[string]RawMessage{}
+		cm.keyPaths = map[string]string{}
+	}
+	for key, raw := range messages.Set {
+		if previous, found := cm.keyPaths[key]; found {
+			return fmt.Errorf("duplicate message key %q for language %s in %s and %s", key, file.lang, previous, file.path)
+		}
+		cm.keyPaths[key] = file.path
+		cm.messages.Set[key] = raw
+	}
+	return nil
+}
+
+// decodeMessagesFile decodes a catalog file with the decoder for its extension. With nestedKeys, nested
+// set entries are flattened into dotted keys (see UnmarshalNestedMessages).
+func decodeMessagesFile(fileName string, data []byte, nestedKeys bool) (Messages, error) {
+	var messages Messages
+	decode, ok := catalogDecoders[strings.ToLower(filepath.Ext(fileName))]
+	if !ok {
+		return messages, fmt.Errorf("unsupported message file %s", fileName)
+	}
+	yamlData, err := decode(data)
+	if err == nil {
+		if nestedKeys {
+			_, err = UnmarshalNestedMessages(yamlData, &messages)
+		} else {
+			err = yaml.Unmarshal(yamlData, &messages)
+		}
+	}
+	if err != nil {
+		return messages, fmt.Errorf("failed to unmarshal messages from %s: %v", fileName, err)
+	}
+	return messages, nil
+}
+
+func yamlToYAML(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+// jsonToYAML and tomlToYAML re-encode a generic document as YAML, so JSON and TOML get the same field
+// names and int-or-string handling for code and group as YAML.
+func jsonToYAML(data []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(jsonNumbers(doc))
+}
+
+// jsonNumbers replaces json.Number values with int64 (or float64 when not an integer), so codes like
+// 1234567 stay integers instead of float64 in exponent form.
+func jsonNumbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			t[key] = jsonNumbers(value)
+		}
+	case []interface{}:
+		for i, value := range t {
+			t[i] = jsonNumbers(value)
+		}
+	case json.Number:
+		if n, err := t.Int64(); err == nil {
+			return n
+		}
+		if f, err := t.Float64(); err == nil {
+			return f
+		}
+		return t.String()
+	}
+	return v
+}
+
+func tomlToYAML(data []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
@@ -0,0 +1,19 @@
+package msgcat
+
+import "testing"
+
+func TestDecodeMessagesFile_jsonLargeNumbers(t *testing.T) {
+	data := []byte(`{"default": {"short": "Error", "code": 1234567}, "set": {
+		"payment.failed": {"short": "Payment failed", "code": 9007199254740993, "status": 402}}}`)
+	messages, err := decodeMessagesFile("en.json", data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if messages.Default.Code != "1234567" {
+		t.Errorf("default code = %q, want 1234567", messages.Default.Code)
+	}
+	entry := messages.Set["payment.failed"]
+	if entry.Code != "9007199254740993" || entry.Status != 402 {
+		t.Errorf("payment.failed = %+v", entry)
+	}
+}
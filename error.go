@@ -8,14 +8,78 @@ type Error interface {
 	ErrorKey() string  // Message key (e.g. "error.not_found"); use as identifier when ErrorCode() is empty.
 	GetShortMessage() string
 	GetLongMessage() string
+	Lang() string          // Resolved language of the messages; empty when the language is missing.
+	RequestedLang() string // Normalized language requested from context.
+	IsFallback() bool      // True when the resolved language differs from the requested one.
+	IsMissing() bool       // True when the key or language was not found in the catalog.
+	HTTPStatus() int       // Optional HTTP status from the catalog entry; 0 when not set.
+	Params() Params        // Copy of the params used for rendering; nil when none.
+}
+
+// RedactedParamValue replaces sensitive param values in RedactParams and DefaultError.RedactedParams.
+const RedactedParamValue = "[REDACTED]"
+
+// RedactParams returns a copy of params with the values of keys replaced by RedactedParamValue.
+// Keys that are not present are ignored.
+func RedactParams(params Params, keys ...string) Params {
+	out := copyParams(params)
+	for _, key := range keys {
+		if _, ok := out[key]; ok {
+			out[key] = RedactedParamValue
+		}
+	}
+	return out
+}
+
+// KeyError is a sentinel for matching catalog errors by message key with errors.Is, regardless of
+// language or params: errors.Is(err, msgcat.KeyError("error.not_found")).
+type KeyError string
+
+func (k KeyError) Error() string {
+	return string(k)
+}
+
+// HasKey reports whether err or any error in its tree (Unwrap() error and Unwrap() []error, e.g. errors.Join)
+// is a catalog error with message key key.
+func HasKey(err error, key string) bool {
+	if err == nil {
+		return false
+	}
+	if keyed, ok := err.(interface{ ErrorKey() string }); ok && keyed.ErrorKey() == key {
+		return true
+	}
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		return HasKey(wrapped.Unwrap(), key)
+	case interface{ Unwrap() []error }:
+		for _, inner := range wrapped.Unwrap() {
+			if HasKey(inner, key) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 type DefaultError struct {
-	err          error
-	shortMessage string
-	longMessage  string
-	code         string
-	key          string
+	err           error
+	shortMessage  string
+	longMessage   string
+	code          string
+	key           string
+	lang          string
+	requestedLang string
+	fallback      bool
+	missing       bool
+	status        int
+	params        Params
+	opts          errorOptions
+}
+
+// errorOptions carries catalog settings that travel with the errors it creates.
+type errorOptions struct {
+	sensitiveParams []string
+	logParams       bool
 }
 
 func (ce DefaultError) Error() string {
@@ -26,6 +90,13 @@ func (ce *DefaultError) Unwrap() error {
 	return ce.err
 }
 
+// Is reports whether target is a KeyError naming this error's message key, so errors.Is matches
+// catalog errors by key.
+func (ce *DefaultError) Is(target error) bool {
+	key, ok := target.(KeyError)
+	return ok && ce.key == string(key)
+}
+
 func (ce *DefaultError) ErrorCode() string {
 	return ce.code
 }
@@ -42,6 +113,74 @@ func (ce *DefaultError) GetLongMessage() string {
 	return ce.longMessage
 }
 
-func newCatalogError(code string, key string, shortMessage string, longMessage string, err error) error {
-	return &DefaultError{shortMessage: shortMessage, longMessage: longMessage, code: code, key: key, err: err}
+func (ce *DefaultError) Lang() string {
+	return ce.lang
+}
+
+func (ce *DefaultError) RequestedLang() string {
+	return ce.requestedLang
+}
+
+func (ce *DefaultError) IsFallback() bool {
+	return ce.fallback
+}
+
+func (ce *DefaultError) IsMissing() bool {
+	return ce.missing
+}
+
+func (ce *DefaultError) HTTPStatus() int {
+	return ce.status
+}
+
+// Params returns a copy of the params used for rendering (e.g. for logging or re-localization).
+func (ce *DefaultError) Params() Params {
+	return copyParams(ce.params)
+}
+
+// RedactedParams returns a copy of the params with Config.SensitiveParams and extra keys redacted.
+// Prefer it over Params for logs and API responses.
+func (ce *DefaultError) RedactedParams(extra ...string) Params {
+	return RedactParams(RedactParams(ce.params, ce.opts.sensitiveParams...), extra...)
+}
+
+// NewError returns a catalog error for msgKey that is not rendered yet, so domain packages can create
+// catalog errors without access to the catalog or a request context. Until it is passed through
+// MessageCatalog.Localize, its short and long messages are the key itself.
+func NewError(msgKey string, params Params, cause error) error {
+	return &DefaultError{
+		shortMessage: msgKey,
+		longMessage:  msgKey,
+		key:          msgKey,
+		params:       copyParams(params),
+		err:          cause,
+	}
+}
+
+func copyParams(params Params) Params {
+	if params == nil {
+		return nil
+	}
+	out := make(Params, len(params))
+	for k, v := range params {
+		out[k] = v
+	}
+	return out
+}
+
+func newCatalogError(message *Message, params Params, opts errorOptions, err error) error {
+	return &DefaultError{
+		shortMessage:  message.ShortText,
+		longMessage:   message.LongText,
+		code:          message.Code,
+		key:           message.Key,
+		lang:          message.Lang,
+		requestedLang: message.RequestedLang,
+		fallback:      message.Fallback,
+		missing:       message.Missing,
+		status:        message.Status,
+		params:        copyParams(params),
+		opts:          opts,
+		err:           err,
+	}
 }
@@ -45,7 +45,7 @@ set:
 		panic(err)
 	}
 
-	ctx := context.WithValue(context.Background(), "language", "en")
+	ctx := msgcat.WithLanguage(context.Background(), "en")
 
 	// GetMessageWithCtx with nil params (no placeholders)
 	msg := catalog.GetMessageWithCtx(ctx, "greeting.hello", nil)
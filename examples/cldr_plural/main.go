@@ -43,7 +43,7 @@ set:
 		panic(err)
 	}
 
-	ctx := context.WithValue(context.Background(), "language", "en")
+	ctx := msgcat.WithLanguage(context.Background(), "en")
 
 	for _, count := range []int{0, 1, 2, 5} {
 		msg := catalog.GetMessageWithCtx(ctx, "person.cats", msgcat.Params{"name": "Nick", "count": count})
@@ -64,7 +64,7 @@ set:
 	}
 	msgcat.Reload(catalog)
 
-	ctxAR := context.WithValue(context.Background(), "language", "ar")
+	ctxAR := msgcat.WithLanguage(context.Background(), "ar")
 	for _, count := range []int{0, 1, 2, 5, 11, 100} {
 		msgDog := catalog.GetMessageWithCtx(ctxAR, "person.dogs", msgcat.Params{"name": "Nick", "count": count})
 		fmt.Printf("AR dogs count=%d: %s\n", count, msgDog.ShortText)
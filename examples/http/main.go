@@ -1,34 +1,13 @@
 package main
 
 import (
-	"context"
 	"log"
 	"net/http"
-	"strings"
 
 	"github.com/loopcontext/msgcat"
+	"github.com/loopcontext/msgcat/msgcathttp"
 )
 
-func languageMiddleware(next http.Handler, key msgcat.ContextKey) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		lang := parseAcceptLanguage(r.Header.Get("Accept-Language"))
-		ctx := context.WithValue(r.Context(), key, lang)
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
-}
-
-func parseAcceptLanguage(header string) string {
-	if header == "" {
-		return "en"
-	}
-	part := strings.Split(header, ",")[0]
-	part = strings.TrimSpace(strings.Split(part, ";")[0])
-	if part == "" {
-		return "en"
-	}
-	return part
-}
-
 func main() {
 	catalog, err := msgcat.NewMessageCatalog(msgcat.Config{
 		ResourcePath:      "./resources/messages",
@@ -47,7 +26,9 @@ func main() {
 		_, _ = w.Write([]byte(msg.ShortText))
 	})
 
-	http.Handle("/", languageMiddleware(h, "language"))
+	// Negotiates ?lang=, the "lang" cookie, then Accept-Language; remembers ?lang= in the cookie.
+	languageMiddleware := msgcathttp.Middleware(catalog, msgcathttp.Config{PersistCookie: true})
+	http.Handle("/", languageMiddleware(h))
 	log.Println("listening on :8080")
 	_ = http.ListenAndServe(":8080", nil)
 }
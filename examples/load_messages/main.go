@@ -47,7 +47,7 @@ set:
 		panic(err)
 	}
 
-	ctx := context.WithValue(context.Background(), "language", "en")
+	ctx := msgcat.WithLanguage(context.Background(), "en")
 
 	// Use YAML-loaded key
 	msg := catalog.GetMessageWithCtx(ctx, "greeting.hello", nil)
@@ -68,7 +68,7 @@ set:
 		panic(err)
 	}
 
-	ctx := context.WithValue(context.Background(), "language", "en")
+	ctx := msgcat.WithLanguage(context.Background(), "en")
 	_ = personCats
 	_ = itemsCount
 
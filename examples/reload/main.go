@@ -38,7 +38,7 @@ set:
 		panic(err)
 	}
 
-	ctx := context.WithValue(context.Background(), "language", "en")
+	ctx := msgcat.WithLanguage(context.Background(), "en")
 	msg := catalog.GetMessageWithCtx(ctx, "greeting.hello", nil)
 	fmt.Println("Before reload:", msg.ShortText)
 
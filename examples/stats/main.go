@@ -38,7 +38,7 @@ set:
 		panic(err)
 	}
 
-	ctx := context.WithValue(context.Background(), "language", "en")
+	ctx := msgcat.WithLanguage(context.Background(), "en")
 
 	_ = catalog.GetMessageWithCtx(ctx, "greeting.hello", nil)
 	_ = catalog.GetMessageWithCtx(ctx, "missing.key", nil)
@@ -60,7 +60,7 @@ set:
 	}
 	defer func() { _ = msgcat.Close(catalog) }()
 
-	ctx := context.WithValue(context.Background(), "language", "en")
+	ctx := msgcat.WithLanguage(context.Background(), "en")
 
 	// Missing param "role" => <missing:role>
 	msg := catalog.GetMessageWithCtx(ctx, "greeting.template", msgcat.Params{"name": "juan"})
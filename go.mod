@@ -3,10 +3,12 @@ module github.com/loopcontext/msgcat
 go 1.26
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/golang/mock v1.4.4
 	github.com/onsi/ginkgo v1.14.2
 	github.com/onsi/gomega v1.10.4
 	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
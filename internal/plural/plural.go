@@ -4,9 +4,32 @@ package plural
 
 import "strings"
 
-// Form returns the CLDR plural form for the given language tag and count.
-// Language tag is normalized to base (e.g. "en-US" -> "en"). Unknown languages default to "other".
-func Form(lang string, count int) string {
+// rule is the plural rule of a language family: form selection, the forms it uses (in CLDR order,
+// which is also the gettext msgstr[n] index order), and the equivalent gettext Plural-Forms expression.
+type rule struct {
+	form    func(n int) string
+	forms   []string
+	gettext string
+}
+
+var (
+	ruleOther    = rule{func(int) string { return "other" }, []string{"other"}, "nplurals=1; plural=0;"}
+	ruleOneOther = rule{formOneOther, []string{"one", "other"}, "nplurals=2; plural=(n != 1);"}
+	ruleFrench   = rule{formFrench, []string{"one", "other"}, "nplurals=2; plural=(n > 1);"}
+	ruleArabic   = rule{formArabic, []string{"zero", "one", "two", "few", "many", "other"},
+		"nplurals=6; plural=(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n>=3 && n<=10 ? 3 : n>=11 && n<=99 ? 4 : 5);"}
+	ruleRussian = rule{formRussian, []string{"one", "few", "many"},
+		"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 1 : 2);"}
+	rulePolish = rule{formPolish, []string{"one", "few", "many"},
+		"nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 1 : 2);"}
+	ruleWelsh = rule{formWelsh, []string{"zero", "one", "two", "few", "many", "other"},
+		"nplurals=6; plural=(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n==3 ? 3 : n==6 ? 4 : 5);"}
+	ruleHebrew = rule{formHebrew, []string{"one", "two", "few", "many", "other"},
+		"nplurals=5; plural=(n==1 ? 0 : n==2 ? 1 : n>=3 && n<=10 ? 2 : n>=11 && n<=99 ? 3 : 4);"}
+)
+
+// ruleFor returns the rule for a language tag, normalized to base (e.g. "en-US" -> "en").
+func ruleFor(lang string) rule {
 	base := strings.ToLower(strings.TrimSpace(lang))
 	if idx := strings.Index(base, "-"); idx > 0 {
 		base = base[:idx]
@@ -14,28 +37,46 @@ func Form(lang string, count int) string {
 	if idx := strings.Index(base, "_"); idx > 0 {
 		base = base[:idx]
 	}
-	n := count
-	if n < 0 {
-		n = -n
-	}
 	switch base {
 	case "ar":
-		return formArabic(n)
+		return ruleArabic
 	case "ru", "uk", "be", "sr", "hr", "bs", "sh":
-		return formRussian(n)
+		return ruleRussian
 	case "pl":
-		return formPolish(n)
+		return rulePolish
 	case "fr", "pt", "oc", "it":
-		return formFrench(n)
+		return ruleFrench
 	case "cy", "br", "ga", "gd", "gv", "kw", "mt", "sm", "ak":
-		return formWelsh(n)
+		return ruleWelsh
 	case "he", "iw":
-		return formHebrew(n)
+		return ruleHebrew
 	case "en", "es", "de", "nl", "no", "sv", "da", "fi", "tr", "el", "ja", "ko", "zh", "th", "vi", "id", "hi":
-		return formOneOther(n)
+		return ruleOneOther
 	default:
-		return "other"
+		return ruleOther
+	}
+}
+
+// Form returns the CLDR plural form for the given language tag and count.
+// Language tag is normalized to base (e.g. "en-US" -> "en"). Unknown languages default to "other".
+func Form(lang string, count int) string {
+	n := count
+	if n < 0 {
+		n = -n
 	}
+	return ruleFor(lang).form(n)
+}
+
+// Forms returns the plural forms a language uses, in CLDR order (zero, one, two, few, many, other).
+// The order matches the msgstr[n] indexes of GettextPluralForms.
+func Forms(lang string) []string {
+	return append([]string(nil), ruleFor(lang).forms...)
+}
+
+// GettextPluralForms returns the gettext Plural-Forms header value for a language
+// (e.g. "nplurals=2; plural=(n != 1);").
+func GettextPluralForms(lang string) string {
+	return ruleFor(lang).gettext
 }
 
 func formOneOther(n int) string {
@@ -95,10 +136,8 @@ func formPolish(n int) string {
 	if n10 >= 2 && n10 <= 4 && (n100 < 12 || n100 > 14) {
 		return "few"
 	}
-	if n10 == 0 || (n10 >= 5 && n10 <= 9) || (n100 >= 12 && n100 <= 14) {
-		return "many"
-	}
-	return "other"
+	// Every other integer (0, 5-21, 25-31, ...) is "many"; CLDR "other" only covers fractions.
+	return "many"
 }
 
 func formWelsh(n int) string {
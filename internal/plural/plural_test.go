@@ -32,6 +32,8 @@ func TestForm(t *testing.T) {
 		{"pl", 2, "few"},
 		{"pl", 5, "many"},
 		{"pl", 12, "many"},
+		{"pl", 21, "many"},
+		{"pl", 22, "few"},
 		{"cy", 0, "zero"},
 		{"cy", 1, "one"},
 		{"cy", 2, "two"},
@@ -47,3 +49,34 @@ func TestForm(t *testing.T) {
 		}
 	}
 }
+
+func TestFormsMatchGettextOrder(t *testing.T) {
+	tests := []struct {
+		lang    string
+		forms   []string
+		samples map[int]int // count -> gettext index
+	}{
+		{"en", []string{"one", "other"}, map[int]int{1: 0, 2: 1}},
+		{"fr", []string{"one", "other"}, map[int]int{0: 0, 2: 1}},
+		{"ru", []string{"one", "few", "many"}, map[int]int{21: 0, 3: 1, 11: 2}},
+		{"pl", []string{"one", "few", "many"}, map[int]int{1: 0, 22: 1, 0: 2, 11: 2, 21: 2, 31: 2, 112: 2}},
+		{"ar", []string{"zero", "one", "two", "few", "many", "other"}, map[int]int{0: 0, 2: 2, 11: 4, 100: 5}},
+		{"xx", []string{"other"}, map[int]int{1: 0}},
+	}
+	for _, tt := range tests {
+		got := Forms(tt.lang)
+		if len(got) != len(tt.forms) {
+			t.Fatalf("Forms(%q) = %v, want %v", tt.lang, got, tt.forms)
+		}
+		for i := range got {
+			if got[i] != tt.forms[i] {
+				t.Errorf("Forms(%q) = %v, want %v", tt.lang, got, tt.forms)
+			}
+		}
+		for count, idx := range tt.samples {
+			if form := Form(tt.lang, count); form != got[idx] {
+				t.Errorf("Form(%q, %d) = %q, want msgstr[%d] %q", tt.lang, count, form, idx, got[idx])
+			}
+		}
+	}
+}
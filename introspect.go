@@ -0,0 +1,107 @@
+package msgcat
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Languages returns the loaded languages (YAML and runtime), sorted.
+func (dmc *DefaultMessageCatalog) Languages() []string {
+	dmc.mu.RLock()
+	defer dmc.mu.RUnlock()
+	langs := make([]string, 0, len(dmc.messages))
+	for lang := range dmc.messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Keys returns the message keys loaded for lang, sorted. No fallback language is used; it returns nil
+// when the language is not loaded.
+func (dmc *DefaultMessageCatalog) Keys(lang string) []string {
+	dmc.mu.RLock()
+	defer dmc.mu.RUnlock()
+	langMsgSet, found := dmc.messages[normalizeLangTag(lang)]
+	if !found {
+		return nil
+	}
+	keys := make([]string, 0, len(langMsgSet.Set))
+	for key := range langMsgSet.Set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Has reports whether lang has an entry for key (no fallback language is used).
+func (dmc *DefaultMessageCatalog) Has(lang string, key string) bool {
+	_, found := dmc.Entry(lang, key)
+	return found
+}
+
+// Entry returns a copy of the raw entry for key in lang, with Key set. No fallback language is used.
+func (dmc *DefaultMessageCatalog) Entry(lang string, key string) (RawMessage, bool) {
+	dmc.mu.RLock()
+	defer dmc.mu.RUnlock()
+	langMsgSet, found := dmc.messages[normalizeLangTag(lang)]
+	if !found {
+		return RawMessage{}, false
+	}
+	raw, found := langMsgSet.Set[key]
+	if !found {
+		return RawMessage{}, false
+	}
+	raw.ShortForms = copyForms(raw.ShortForms)
+	raw.LongForms = copyForms(raw.LongForms)
+	raw.Key = key
+	return raw, true
+}
+
+func copyForms(forms map[string]string) map[string]string {
+	if forms == nil {
+		return nil
+	}
+	out := make(map[string]string, len(forms))
+	for form, tpl := range forms {
+		out[form] = tpl
+	}
+	return out
+}
+
+func Languages(catalog MessageCatalog) ([]string, error) {
+	introspector, ok := catalog.(interface{ Languages() []string })
+	if !ok {
+		return nil, fmt.Errorf("catalog does not support introspection")
+	}
+	return introspector.Languages(), nil
+}
+
+func Keys(catalog MessageCatalog, lang string) ([]string, error) {
+	introspector, ok := catalog.(interface{ Keys(lang string) []string })
+	if !ok {
+		return nil, fmt.Errorf("catalog does not support introspection")
+	}
+	return introspector.Keys(lang), nil
+}
+
+func Has(catalog MessageCatalog, lang string, key string) (bool, error) {
+	introspector, ok := catalog.(interface {
+		Has(lang string, key string) bool
+	})
+	if !ok {
+		return false, fmt.Errorf("catalog does not support introspection")
+	}
+	return introspector.Has(lang, key), nil
+}
+
+func Entry(catalog MessageCatalog, lang string, key string) (RawMessage, bool, error) {
+	introspector, ok := catalog.(interface {
+		Entry(lang string, key string) (RawMessage, bool)
+	})
+	if !ok {
+		return RawMessage{}, false, fmt.Errorf("catalog does not support introspection")
+	}
+	raw, found := introspector.Entry(lang, key)
+	return raw, found, nil
+}
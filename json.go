@@ -0,0 +1,54 @@
+package msgcat
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// errorJSON is the wire form of DefaultError. The cause is never encoded; params are redacted with
+// Config.SensitiveParams.
+type errorJSON struct {
+	Key    string `json:"key"`
+	Code   string `json:"code"`
+	Short  string `json:"short"`
+	Long   string `json:"long"`
+	Lang   string `json:"lang"`
+	Status int    `json:"status,omitempty"`
+	Params Params `json:"params,omitempty"`
+}
+
+// MarshalJSON encodes the error as {"key","code","short","long","lang"} plus status and (redacted)
+// params when set, so it can cross service boundaries. The wrapped cause is not encoded.
+func (ce DefaultError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorJSON{
+		Key:    ce.key,
+		Code:   ce.code,
+		Short:  ce.shortMessage,
+		Long:   ce.longMessage,
+		Lang:   ce.lang,
+		Status: ce.status,
+		Params: ce.RedactedParams(),
+	})
+}
+
+// UnmarshalJSON reconstructs an error encoded by MarshalJSON. The result matches errors.Is with
+// KeyError and can be re-rendered in another language with MessageCatalog.Localize.
+func (ce *DefaultError) UnmarshalJSON(data []byte) error {
+	var wire errorJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	if wire.Key == "" {
+		return fmt.Errorf("msgcat: error JSON is missing key")
+	}
+	*ce = DefaultError{
+		key:          wire.Key,
+		code:         wire.Code,
+		shortMessage: wire.Short,
+		longMessage:  wire.Long,
+		lang:         wire.Lang,
+		status:       wire.Status,
+		params:       wire.Params,
+	}
+	return nil
+}
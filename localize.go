@@ -0,0 +1,79 @@
+package msgcat
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// localizedWrapError stands in for a non-catalog wrapper (e.g. fmt.Errorf with %w) whose inner chain
+// was localized; the wrapper's text is kept with the inner error's text replaced.
+type localizedWrapError struct {
+	msg   string
+	inner error
+}
+
+func (e *localizedWrapError) Error() string {
+	return e.msg
+}
+
+func (e *localizedWrapError) Unwrap() error {
+	return e.inner
+}
+
+// Localize renders every catalog error in err's tree in the context language, using each error's key
+// and params; causes are kept. Use it at the API edge for errors created with NewError or rendered in
+// another language. Non-catalog wrappers are rebuilt: single wrappers keep their text with the inner
+// text replaced, multi-error wrappers become errors.Join. Returns nil for nil.
+func (dmc *DefaultMessageCatalog) Localize(ctx context.Context, err error) error {
+	return localizeError(err, func(cause error, msgKey string, params Params) error {
+		return dmc.WrapErrorWithCtx(ctx, cause, msgKey, params)
+	})
+}
+
+// wrapFunc renders msgKey with params into a catalog error wrapping cause.
+type wrapFunc func(cause error, msgKey string, params Params) error
+
+func localizeError(err error, wrap wrapFunc) error {
+	localized, _ := localizeTree(err, wrap)
+	return localized
+}
+
+// localizeTree returns the localized tree and whether anything in it changed. It never compares errors
+// with ==, since error values are not guaranteed to be comparable.
+func localizeTree(err error, wrap wrapFunc) (error, bool) {
+	if err == nil {
+		return nil, false
+	}
+	if catErr, ok := err.(*DefaultError); ok {
+		cause, _ := localizeTree(catErr.err, wrap)
+		return wrap(cause, catErr.key, catErr.params), true
+	}
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		inner := wrapped.Unwrap()
+		localized, changed := localizeTree(inner, wrap)
+		if !changed {
+			return err, false
+		}
+		msg := err.Error()
+		if idx := strings.LastIndex(msg, inner.Error()); idx >= 0 {
+			msg = msg[:idx] + localized.Error() + msg[idx+len(inner.Error()):]
+		}
+		return &localizedWrapError{msg: msg, inner: localized}, true
+	case interface{ Unwrap() []error }:
+		inner := wrapped.Unwrap()
+		localized := make([]error, 0, len(inner))
+		changed := false
+		for _, e := range inner {
+			l, c := localizeTree(e, wrap)
+			changed = changed || c
+			localized = append(localized, l)
+		}
+		if !changed {
+			return err, false
+		}
+		return errors.Join(localized...), true
+	}
+	return err, false
+}
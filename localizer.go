@@ -0,0 +1,82 @@
+package msgcat
+
+import "fmt"
+
+// Localizer renders messages and errors in a fixed language without a context.Context. The language
+// chain is resolved once, when the Localizer is created; it is safe for concurrent use and can be reused
+// for batch work (emails, PDFs) that renders many messages for a known user language.
+type Localizer struct {
+	catalog       *DefaultMessageCatalog
+	requestedLang string
+	lang          string
+	found         bool
+	fallback      bool
+}
+
+// Localizer returns a handle bound to the first loaded language in lang (preference order), falling back
+// through the catalog chain (base tag, FallbackLanguages, DefaultLanguage, "en"). With no lang,
+// DefaultLanguage is used.
+func (dmc *DefaultMessageCatalog) Localizer(lang ...string) *Localizer {
+	requested := lang
+	if len(requested) == 0 {
+		requested = []string{dmc.cfg.DefaultLanguage}
+	}
+	requestedLang := ""
+	for _, candidate := range requested {
+		if requestedLang = normalizeLangTag(candidate); requestedLang != "" {
+			break
+		}
+	}
+	resolvedLang, found, usedFallback := dmc.resolveLanguage(requested...)
+	return &Localizer{
+		catalog:       dmc,
+		requestedLang: requestedLang,
+		lang:          resolvedLang,
+		found:         found,
+		fallback:      usedFallback,
+	}
+}
+
+// NewLocalizer returns a language-bound handle for catalogs that support it (see
+// DefaultMessageCatalog.Localizer).
+func NewLocalizer(catalog MessageCatalog, lang ...string) (*Localizer, error) {
+	localizable, ok := catalog.(interface {
+		Localizer(lang ...string) *Localizer
+	})
+	if !ok {
+		return nil, fmt.Errorf("catalog does not support localizers")
+	}
+	localizer := localizable.Localizer(lang...)
+	if localizer == nil {
+		return nil, fmt.Errorf("catalog returned no localizer")
+	}
+	return localizer, nil
+}
+
+// Lang returns the resolved language, or empty when no language in the chain is loaded.
+func (l *Localizer) Lang() string {
+	if !l.found {
+		return ""
+	}
+	return l.lang
+}
+
+// Message resolves msgKey in the bound language; never nil. params can be nil.
+func (l *Localizer) Message(msgKey string, params Params) *Message {
+	return l.catalog.getMessage(l.requestedLang, l.lang, l.found, l.fallback, msgKey, params)
+}
+
+// Error builds an error with localized short/long text (no inner error).
+func (l *Localizer) Error(msgKey string, params Params) error {
+	return l.Wrap(nil, msgKey, params)
+}
+
+// Wrap wraps err with localized short/long text and message code.
+func (l *Localizer) Wrap(err error, msgKey string, params Params) error {
+	return newCatalogError(l.Message(msgKey, params), params, l.catalog.errorOptions(), err)
+}
+
+// Localize renders every catalog error in err's tree in the bound language. See MessageCatalog.Localize.
+func (l *Localizer) Localize(err error) error {
+	return localizeError(err, l.Wrap)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -11,7 +12,6 @@ import (
 	"time"
 
 	"github.com/loopcontext/msgcat/internal/plural"
-	"gopkg.in/yaml.v2"
 )
 
 //go:generate mockgen -source=$GOFILE -package mock_msgcat -destination=test/mock/$GOFILE
@@ -37,11 +37,21 @@ var (
 )
 
 type MessageCatalog interface {
-	// LoadMessages adds or replaces messages for a language. Keys must have prefix RuntimeKeyPrefix (e.g. "sys.").
+	// LoadMessages adds messages for a language and fails if a key exists. Keys must have prefix RuntimeKeyPrefix (e.g. "sys.").
 	LoadMessages(lang string, messages []RawMessage) error
+	// LoadBatch loads messages for several languages with LoadMessages rules, all or nothing.
+	LoadBatch(batch map[string][]RawMessage) error
+	// UpsertMessages adds or replaces runtime messages (RuntimeKeyPrefix required); all or nothing is applied.
+	UpsertMessages(lang string, messages []RawMessage) error
+	// RemoveMessages removes runtime messages by key; YAML entries are kept.
+	RemoveMessages(lang string, keys ...string) error
+	// ClearRuntime removes all runtime messages for a language (all languages when lang is empty).
+	ClearRuntime(lang string)
 	GetMessageWithCtx(ctx context.Context, msgKey string, params Params) *Message
 	WrapErrorWithCtx(ctx context.Context, err error, msgKey string, params Params) error
 	GetErrorWithCtx(ctx context.Context, msgKey string, params Params) error
+	// Localize renders every catalog error in err's tree (e.g. from NewError) in the context language.
+	Localize(ctx context.Context, err error) error
 }
 
 type observerEventType int
@@ -168,40 +178,52 @@ type DefaultMessageCatalog struct {
 	mu              sync.RWMutex
 	messages        map[string]Messages // language -> messages (Set keyed by message key)
 	runtimeMessages map[string]map[string]RawMessage
+	yamlOverridden  map[string]map[string]RawMessage // language -> YAML entries replaced by UpsertMessages
 	cfg             Config
 	stats           catalogStats
 	observerCh      chan observerEvent
 	observerDone    chan struct{}
 }
 
-func (dmc *DefaultMessageCatalog) readMessagesFromYaml() (map[string]Messages, error) {
+// readMessageFiles reads the message files of every language from ResourcePath (see listCatalogFiles)
+// and merges them per language; duplicate keys across files are an error naming both files.
+func (dmc *DefaultMessageCatalog) readMessageFiles() (map[string]Messages, error) {
 	resourcePath := dmc.cfg.ResourcePath
 	if resourcePath == "" {
 		resourcePath = "./resources/messages"
 	}
 
-	messageFiles, err := os.ReadDir(resourcePath)
+	messageFiles, err := listCatalogFiles(resourcePath, dmc.cfg.SplitFiles)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find messages %v", err)
 	}
 
-	messageByLang := map[string]Messages{}
+	mergeByLang := map[string]*catalogMerge{}
+	var langs []string
 
 	for _, messageFile := range messageFiles {
-		fileName := messageFile.Name()
-		if !strings.HasSuffix(fileName, ".yaml") {
-			continue
-		}
-		var messages Messages
-		lang := normalizeLangTag(strings.TrimSuffix(fileName, ".yaml"))
-		yamlFile, err := os.ReadFile(fmt.Sprintf("%s/%s", resourcePath, fileName))
+		data, err := os.ReadFile(filepath.Join(resourcePath, messageFile.path))
 		if err != nil {
 			return nil, fmt.Errorf("failed to read message file: %v", err)
 		}
-		err = yaml.Unmarshal(yamlFile, &messages)
+		messages, err := decodeMessagesFile(messageFile.path, data, dmc.cfg.NestedKeys)
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal messages: %v", err)
+			return nil, err
+		}
+		merge, found := mergeByLang[messageFile.lang]
+		if !found {
+			merge = &catalogMerge{}
+			mergeByLang[messageFile.lang] = merge
+			langs = append(langs, messageFile.lang)
+		}
+		if err := merge.add(messageFile, messages); err != nil {
+			return nil, err
 		}
+	}
+
+	messageByLang := map[string]Messages{}
+	for _, lang := range langs {
+		messages := mergeByLang[lang].messages
 		if err := normalizeAndValidateMessages(lang, &messages); err != nil {
 			return nil, err
 		}
@@ -211,7 +233,7 @@ func (dmc *DefaultMessageCatalog) readMessagesFromYaml() (map[string]Messages, e
 	return messageByLang, nil
 }
 
-func (dmc *DefaultMessageCatalog) readMessagesFromYamlWithRetry() (map[string]Messages, error) {
+func (dmc *DefaultMessageCatalog) readMessageFilesWithRetry() (map[string]Messages, error) {
 	retries := dmc.cfg.ReloadRetries
 	if retries < 0 {
 		retries = 0
@@ -223,7 +245,7 @@ func (dmc *DefaultMessageCatalog) readMessagesFromYamlWithRetry() (map[string]Me
 
 	var lastErr error
 	for attempt := 0; attempt <= retries; attempt++ {
-		messageByLang, err := dmc.readMessagesFromYaml()
+		messageByLang, err := dmc.readMessageFiles()
 		if err == nil {
 			return messageByLang, nil
 		}
@@ -237,13 +259,14 @@ func (dmc *DefaultMessageCatalog) readMessagesFromYamlWithRetry() (map[string]Me
 }
 
 func (dmc *DefaultMessageCatalog) loadFromYaml() error {
-	messageByLang, err := dmc.readMessagesFromYamlWithRetry()
+	messageByLang, err := dmc.readMessageFilesWithRetry()
 	if err != nil {
 		return err
 	}
 
 	dmc.mu.Lock()
 	defer dmc.mu.Unlock()
+	yamlOverridden := map[string]map[string]RawMessage{}
 	if dmc.runtimeMessages != nil {
 		for lang, runtimeSet := range dmc.runtimeMessages {
 			msgSet, found := messageByLang[lang]
@@ -254,12 +277,19 @@ func (dmc *DefaultMessageCatalog) loadFromYaml() error {
 				msgSet.Set = map[string]RawMessage{}
 			}
 			for key, msg := range runtimeSet {
+				if yamlMsg, found := msgSet.Set[key]; found {
+					if yamlOverridden[lang] == nil {
+						yamlOverridden[lang] = map[string]RawMessage{}
+					}
+					yamlOverridden[lang][key] = yamlMsg
+				}
 				msgSet.Set[key] = msg
 			}
 			messageByLang[lang] = msgSet
 		}
 	}
 	dmc.messages = messageByLang
+	dmc.yamlOverridden = yamlOverridden
 	dmc.stats.setLastReloadAt(dmc.cfg.NowFn())
 
 	return nil
@@ -280,12 +310,23 @@ func normalizeAndValidateMessages(lang string, messages *Messages) error {
 			return fmt.Errorf("invalid message key %q for language %s: must match [a-zA-Z0-9_.-]+", key, lang)
 		}
 		// Code is optional; leave as-is from YAML
+		if !validStatus(raw.Status) {
+			return fmt.Errorf("invalid status %d for message key %q in language %s: must be between 100 and 599", raw.Status, key, lang)
+		}
+		if raw.MaxLength < 0 {
+			return fmt.Errorf("invalid max_length %d for message key %q in language %s: must not be negative", raw.MaxLength, key, lang)
+		}
 		messages.Set[key] = raw
 	}
 
 	return nil
 }
 
+// validStatus reports whether status is unset (0) or a valid HTTP status code.
+func validStatus(status int) bool {
+	return status == 0 || (status >= 100 && status <= 599)
+}
+
 func normalizeLangTag(lang string) string {
 	lang = strings.TrimSpace(strings.ToLower(lang))
 	lang = strings.ReplaceAll(lang, "_", "-")
@@ -654,36 +695,52 @@ func (dmc *DefaultMessageCatalog) onTemplateIssue(lang string, msgKey string, is
 	})
 }
 
-func (dmc *DefaultMessageCatalog) resolveRequestedLang(ctx context.Context) string {
+// resolveRequestedLangs returns the normalized language preference list from ctx (never empty).
+// Order: WithLanguage/WithLanguages, then CtxLanguageKey (typed, then plain string), then DefaultLanguage.
+func (dmc *DefaultMessageCatalog) resolveRequestedLangs(ctx context.Context) []string {
 	lang := normalizeLangTag(dmc.cfg.DefaultLanguage)
 	if lang == "" {
 		lang = "en"
 	}
 	if ctx == nil {
-		return lang
+		return []string{lang}
 	}
 
+	if langs, ok := LanguagesFromContext(ctx); ok {
+		return langs
+	}
 	// Keep backward compatibility with callers that used plain string keys.
 	if langKeyVal := ctx.Value(dmc.cfg.CtxLanguageKey); langKeyVal != nil {
-		return normalizeLangTag(fmt.Sprintf("%v", langKeyVal))
+		return []string{normalizeLangTag(fmt.Sprintf("%v", langKeyVal))}
 	}
 	if langKeyVal := ctx.Value(string(dmc.cfg.CtxLanguageKey)); langKeyVal != nil {
-		return normalizeLangTag(fmt.Sprintf("%v", langKeyVal))
+		return []string{normalizeLangTag(fmt.Sprintf("%v", langKeyVal))}
 	}
 
-	return lang
+	return []string{lang}
 }
 
-func (dmc *DefaultMessageCatalog) resolveLanguage(requestedLang string) (string, bool, bool) {
-	normalizedRequested := normalizeLangTag(requestedLang)
+// resolveLanguage walks the fallback chain for the requested languages (in preference order) and
+// returns the first loaded language, whether one was found, and whether it differs from the first requested.
+func (dmc *DefaultMessageCatalog) resolveLanguage(requestedLangs ...string) (string, bool, bool) {
+	normalizedRequested := ""
+	candidates := make([]string, 0, 2*len(requestedLangs)+4)
+	seen := map[string]struct{}{}
+	for _, requestedLang := range requestedLangs {
+		normalized := normalizeLangTag(requestedLang)
+		if normalized == "" {
+			continue
+		}
+		if normalizedRequested == "" {
+			normalizedRequested = normalized
+		}
+		appendLangIfMissing(&candidates, seen, normalized)
+		appendLangIfMissing(&candidates, seen, baseLangTag(normalized))
+	}
 	if normalizedRequested == "" {
 		normalizedRequested = "en"
+		appendLangIfMissing(&candidates, seen, normalizedRequested)
 	}
-
-	candidates := make([]string, 0, 6)
-	seen := map[string]struct{}{}
-	appendLangIfMissing(&candidates, seen, normalizedRequested)
-	appendLangIfMissing(&candidates, seen, baseLangTag(normalizedRequested))
 	for _, lang := range dmc.cfg.FallbackLanguages {
 		appendLangIfMissing(&candidates, seen, normalizeLangTag(lang))
 	}
@@ -824,74 +881,36 @@ func (dmc *DefaultMessageCatalog) renderTemplate(lang string, msgKey string, tem
 }
 
 func (dmc *DefaultMessageCatalog) LoadMessages(lang string, messages []RawMessage) error {
-	dmc.mu.Lock()
-	defer dmc.mu.Unlock()
-
-	normalizedLang := normalizeLangTag(lang)
-	if normalizedLang == "" {
+	if normalizeLangTag(lang) == "" {
 		return fmt.Errorf("language is required")
 	}
+	return dmc.loadRuntime("LoadMessages", map[string][]RawMessage{lang: messages})
+}
 
-	if dmc.messages == nil {
-		dmc.messages = map[string]Messages{}
-	}
-	if dmc.runtimeMessages == nil {
-		dmc.runtimeMessages = map[string]map[string]RawMessage{}
-	}
-	if _, foundLangMsg := dmc.messages[normalizedLang]; !foundLangMsg {
-		dmc.messages[normalizedLang] = Messages{
-			Set: map[string]RawMessage{},
-		}
-	}
-	if _, foundRuntimeLang := dmc.runtimeMessages[normalizedLang]; !foundRuntimeLang {
-		dmc.runtimeMessages[normalizedLang] = map[string]RawMessage{}
-	}
-
-	langMsgSet := dmc.messages[normalizedLang]
-	if langMsgSet.Set == nil {
-		langMsgSet.Set = map[string]RawMessage{}
-	}
-
-	for _, message := range messages {
-		key := message.Key
-		if key == "" {
-			return fmt.Errorf("LoadMessages: message key is required")
-		}
-		if !strings.HasPrefix(key, RuntimeKeyPrefix) {
-			return fmt.Errorf("LoadMessages: key %q must have prefix %q", key, RuntimeKeyPrefix)
-		}
-		if !messageKeyRegex.MatchString(key) {
-			return fmt.Errorf("LoadMessages: invalid key %q", key)
-		}
-		if _, foundMsg := langMsgSet.Set[key]; foundMsg {
-			return fmt.Errorf("message with key %q already exists in message set for language %s", key, normalizedLang)
-		}
-		normalizedMessage := RawMessage{
-			LongTpl:     message.LongTpl,
-			ShortTpl:    message.ShortTpl,
-			Code:        message.Code,
-			ShortForms:  message.ShortForms,
-			LongForms:   message.LongForms,
-			PluralParam: message.PluralParam,
-		}
-		langMsgSet.Set[key] = normalizedMessage
-		dmc.runtimeMessages[normalizedLang][key] = normalizedMessage
-	}
-	dmc.messages[normalizedLang] = langMsgSet
-
-	return nil
+// LoadBatch loads runtime messages for several languages with LoadMessages rules. Every language and
+// entry is validated first; on any error nothing is applied and the error lists each invalid entry.
+func (dmc *DefaultMessageCatalog) LoadBatch(batch map[string][]RawMessage) error {
+	return dmc.loadRuntime("LoadBatch", batch)
 }
 
 func (dmc *DefaultMessageCatalog) GetMessageWithCtx(ctx context.Context, msgKey string, params Params) *Message {
-	requestedLang := dmc.resolveRequestedLang(ctx)
-	resolvedLang, foundLangMsg, usedFallback := dmc.resolveLanguage(requestedLang)
+	requestedLangs := dmc.resolveRequestedLangs(ctx)
+	resolvedLang, foundLangMsg, usedFallback := dmc.resolveLanguage(requestedLangs...)
+	return dmc.getMessage(requestedLangs[0], resolvedLang, foundLangMsg, usedFallback, msgKey, params)
+}
+
+// getMessage renders msgKey for an already resolved language; shared by GetMessageWithCtx and Localizer.
+func (dmc *DefaultMessageCatalog) getMessage(requestedLang string, resolvedLang string, foundLangMsg bool, usedFallback bool,
+	msgKey string, params Params) *Message {
 	if !foundLangMsg {
 		dmc.onLanguageMissing(requestedLang)
 		return &Message{
-			ShortText: fmt.Sprintf(MessageCatalogNotFound, requestedLang, ""),
-			LongText:  fmt.Sprintf(MessageCatalogNotFound, requestedLang, "Please, contact support."),
-			Code:      CodeMissingLanguage,
-			Key:       msgKey,
+			ShortText:     fmt.Sprintf(MessageCatalogNotFound, requestedLang, ""),
+			LongText:      fmt.Sprintf(MessageCatalogNotFound, requestedLang, "Please, contact support."),
+			Code:          CodeMissingLanguage,
+			Key:           msgKey,
+			RequestedLang: requestedLang,
+			Missing:       true,
 		}
 	}
 	if usedFallback {
@@ -904,21 +923,25 @@ func (dmc *DefaultMessageCatalog) GetMessageWithCtx(ctx context.Context, msgKey
 		dmc.mu.RUnlock()
 		dmc.onLanguageMissing(requestedLang)
 		return &Message{
-			ShortText: fmt.Sprintf(MessageCatalogNotFound, requestedLang, ""),
-			LongText:  fmt.Sprintf(MessageCatalogNotFound, requestedLang, "Please, contact support."),
-			Code:      CodeMissingLanguage,
-			Key:       msgKey,
+			ShortText:     fmt.Sprintf(MessageCatalogNotFound, requestedLang, ""),
+			LongText:      fmt.Sprintf(MessageCatalogNotFound, requestedLang, "Please, contact support."),
+			Code:          CodeMissingLanguage,
+			Key:           msgKey,
+			RequestedLang: requestedLang,
+			Missing:       true,
 		}
 	}
 
 	shortMessage := langMsgSet.Default.ShortTpl
 	longMessage := langMsgSet.Default.LongTpl
 	code := CodeMissingMessage
+	status := 0
 	missingMessage := false
 	if msg, ok := langMsgSet.Set[msgKey]; ok {
 		shortMessage = msg.ShortTpl
 		longMessage = msg.LongTpl
 		code = string(msg.Code)
+		status = msg.Status
 		// CLDR plural forms: when ShortForms/LongForms are set, select by plural param and language
 		if len(msg.ShortForms) > 0 || len(msg.LongForms) > 0 {
 			pluralParam := msg.PluralParam
@@ -949,16 +972,25 @@ func (dmc *DefaultMessageCatalog) GetMessageWithCtx(ctx context.Context, msgKey
 	shortMessage = dmc.renderTemplate(resolvedLang, msgKey, shortMessage, paramMap)
 	longMessage = dmc.renderTemplate(resolvedLang, msgKey, longMessage, paramMap)
 	return &Message{
-		LongText:  longMessage,
-		ShortText: shortMessage,
-		Code:      code,
-		Key:       msgKey,
+		LongText:      longMessage,
+		ShortText:     shortMessage,
+		Code:          code,
+		Key:           msgKey,
+		Lang:          resolvedLang,
+		RequestedLang: requestedLang,
+		Fallback:      usedFallback,
+		Missing:       missingMessage,
+		Status:        status,
 	}
 }
 
 func (dmc *DefaultMessageCatalog) WrapErrorWithCtx(ctx context.Context, err error, msgKey string, params Params) error {
 	message := dmc.GetMessageWithCtx(ctx, msgKey, params)
-	return newCatalogError(message.Code, message.Key, message.ShortText, message.LongText, err)
+	return newCatalogError(message, params, dmc.errorOptions(), err)
+}
+
+func (dmc *DefaultMessageCatalog) errorOptions() errorOptions {
+	return errorOptions{sensitiveParams: dmc.cfg.SensitiveParams, logParams: dmc.cfg.LogParams}
 }
 
 func (dmc *DefaultMessageCatalog) GetErrorWithCtx(ctx context.Context, msgKey string, params Params) error {
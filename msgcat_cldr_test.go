@@ -77,6 +77,36 @@ set:
 	}
 }
 
+func TestGetMessageWithCtx_CLDRForms_polish(t *testing.T) {
+	dir := t.TempDir()
+	pl := []byte(`default:
+  short: Błąd
+  long: Błąd
+set:
+  files:
+    short_forms:
+      one: "{{count}} plik"
+      few: "{{count}} pliki"
+      many: "{{count}} plików"
+`)
+	if err := os.WriteFile(filepath.Join(dir, "pl.yaml"), pl, 0644); err != nil {
+		t.Fatal(err)
+	}
+	catalog, err := NewMessageCatalog(Config{ResourcePath: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.WithValue(context.Background(), "language", "pl")
+
+	// Counts ending in 1 (other than 1) are many, not other, which Polish catalogs do not define.
+	for count, want := range map[int]string{1: "1 plik", 2: "2 pliki", 5: "5 plików", 21: "21 plików", 22: "22 pliki", 101: "101 plików"} {
+		msg := catalog.GetMessageWithCtx(ctx, "files", Params{"count": count})
+		if msg.ShortText != want {
+			t.Errorf("count=%d short: got %q, want %q", count, msg.ShortText, want)
+		}
+	}
+}
+
 func TestLoadMessages_preservesShortFormsLongFormsPluralParam(t *testing.T) {
 	dir := t.TempDir()
 	en := []byte(`default:
@@ -0,0 +1,212 @@
+// Package msgcathttp provides net/http helpers for msgcat: language negotiation middleware that stores the
+// negotiated language in the request context for GetMessageWithCtx and friends.
+package msgcathttp
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loopcontext/msgcat"
+)
+
+// Source is a place the middleware reads a requested language from.
+type Source int
+
+const (
+	SourceQuery  Source = iota // URL query parameter (Config.QueryParam).
+	SourceCookie               // Cookie (Config.CookieName).
+	SourceHeader               // Accept-Language header.
+
+	SourceDefault Source = -1 // No source matched; the default language was used.
+)
+
+// Config configures the language middleware. The zero value is usable: query "lang", cookie "lang",
+// then Accept-Language, restricted to the catalog's loaded languages, no cookie persistence.
+type Config struct {
+	Order          []Source          // Negotiation order. Default: query, cookie, header.
+	QueryParam     string            // Query parameter name. Default "lang".
+	CookieName     string            // Cookie name. Default "lang".
+	Languages      []string          // Optional allow-list; when empty, the catalog's loaded languages are used.
+	CtxLanguageKey msgcat.ContextKey // Optional; also store the language under this key for legacy readers.
+	PersistCookie  bool              // Set the cookie when the language was chosen via the query parameter.
+	CookieMaxAge   time.Duration     // Cookie lifetime when persisting. Default one year.
+	CookiePath     string            // Cookie path when persisting. Default "/".
+	CookieSecure   bool              // Mark the persisted cookie Secure.
+}
+
+func (c Config) withDefaults() Config {
+	if len(c.Order) == 0 {
+		c.Order = []Source{SourceQuery, SourceCookie, SourceHeader}
+	}
+	if c.QueryParam == "" {
+		c.QueryParam = "lang"
+	}
+	if c.CookieName == "" {
+		c.CookieName = "lang"
+	}
+	if c.CookieMaxAge <= 0 {
+		c.CookieMaxAge = 365 * 24 * time.Hour
+	}
+	if c.CookiePath == "" {
+		c.CookiePath = "/"
+	}
+	return c
+}
+
+// Middleware negotiates the request language and stores it with msgcat.WithLanguage (and under
+// CtxLanguageKey when set), so catalog lookups with r.Context() use it. It sets Content-Language to
+// the negotiated language and adds Vary: Accept-Language.
+func Middleware(catalog msgcat.MessageCatalog, cfg Config) func(http.Handler) http.Handler {
+	cfg = cfg.withDefaults()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang, source := Negotiate(catalog, r, cfg)
+			w.Header().Add("Vary", "Accept-Language")
+			if lang == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("Content-Language", lang)
+			if cfg.PersistCookie && source == SourceQuery {
+				if current, err := r.Cookie(cfg.CookieName); err != nil || current.Value != lang {
+					http.SetCookie(w, &http.Cookie{
+						Name:     cfg.CookieName,
+						Value:    lang,
+						Path:     cfg.CookiePath,
+						MaxAge:   int(cfg.CookieMaxAge / time.Second),
+						Secure:   cfg.CookieSecure,
+						HttpOnly: true,
+						SameSite: http.SameSiteLaxMode,
+					})
+				}
+			}
+			ctx := msgcat.WithLanguage(r.Context(), lang)
+			if cfg.CtxLanguageKey != "" {
+				ctx = context.WithValue(ctx, cfg.CtxLanguageKey, lang)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Negotiate returns the language for r and the source it came from. Sources are tried in cfg.Order;
+// the first one naming a supported language (exact or base tag, e.g. "es-AR" -> "es") wins. When no
+// source matches, the first Config.Languages entry (or the catalog default) is returned with
+// SourceDefault. lang is empty when the catalog has no languages loaded.
+func Negotiate(catalog msgcat.MessageCatalog, r *http.Request, cfg Config) (lang string, source Source) {
+	cfg = cfg.withDefaults()
+	for _, src := range cfg.Order {
+		var candidates []string
+		switch src {
+		case SourceQuery:
+			if v := r.URL.Query().Get(cfg.QueryParam); v != "" {
+				candidates = []string{v}
+			}
+		case SourceCookie:
+			if c, err := r.Cookie(cfg.CookieName); err == nil && c.Value != "" {
+				candidates = []string{c.Value}
+			}
+		case SourceHeader:
+			candidates = ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		}
+		if matched, ok := cfg.match(catalog, candidates); ok {
+			return matched, src
+		}
+	}
+	if len(cfg.Languages) > 0 {
+		return normalizeTag(cfg.Languages[0]), SourceDefault
+	}
+	return resolvedLang(catalog), SourceDefault
+}
+
+// resolvedLang returns the language the catalog resolves for langs (its default when empty), or empty
+// when the catalog has no localizer.
+func resolvedLang(catalog msgcat.MessageCatalog, langs ...string) string {
+	localizer, err := msgcat.NewLocalizer(catalog, langs...)
+	if err != nil {
+		return ""
+	}
+	return localizer.Lang()
+}
+
+// match returns the first candidate (or its base tag) that is supported: in Config.Languages, or else
+// loaded in the catalog. A catalog that cannot list its languages accepts the first candidate and
+// falls back at lookup time.
+func (c Config) match(catalog msgcat.MessageCatalog, candidates []string) (string, bool) {
+	if len(candidates) == 0 {
+		return "", false
+	}
+	languages := c.Languages
+	if len(languages) == 0 {
+		loaded, err := msgcat.Languages(catalog)
+		if err != nil {
+			return normalizeTag(candidates[0]), true
+		}
+		languages = loaded
+	}
+	supported := make(map[string]struct{}, len(languages))
+	for _, lang := range languages {
+		supported[normalizeTag(lang)] = struct{}{}
+	}
+	for _, candidate := range candidates {
+		tag := normalizeTag(candidate)
+		for _, option := range []string{tag, baseTag(tag)} {
+			if _, ok := supported[option]; ok {
+				return option, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language header ordered by quality
+// (highest first, header order for ties). Wildcards and tags with q=0 are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	out := make([]string, len(tags))
+	for i, t := range tags {
+		out[i] = t.tag
+	}
+	return out
+}
+
+func normalizeTag(lang string) string {
+	return strings.ReplaceAll(strings.TrimSpace(strings.ToLower(lang)), "_", "-")
+}
+
+func baseTag(lang string) string {
+	if idx := strings.Index(lang, "-"); idx > 0 {
+		return lang[:idx]
+	}
+	return lang
+}
@@ -0,0 +1,132 @@
+package msgcathttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/loopcontext/msgcat"
+)
+
+func newTestCatalog(t *testing.T) msgcat.MessageCatalog {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"en.yaml": "default:\n  short: Unexpected error\n  long: Unexpected error\nset:\n  greeting.hello:\n    short: Hello\n    long: Hello there\n",
+		"es.yaml": "default:\n  short: Error inesperado\n  long: Error inesperado\nset:\n  greeting.hello:\n    short: Hola\n    long: Hola a todos\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	catalog, err := msgcat.NewMessageCatalog(msgcat.Config{ResourcePath: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = msgcat.Close(catalog) })
+	return catalog
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{"", []string{}},
+		{"es-AR,es;q=0.9,en;q=0.8", []string{"es-AR", "es", "en"}},
+		{"en;q=0.5, fr, *;q=0.1", []string{"fr", "en"}},
+		{"de;q=0, pt", []string{"pt"}},
+	}
+	for _, tt := range tests {
+		got := ParseAcceptLanguage(tt.header)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseAcceptLanguage(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestMiddleware_negotiation(t *testing.T) {
+	catalog := newTestCatalog(t)
+	tests := []struct {
+		name   string
+		target string
+		cookie string
+		header string
+		cfg    Config
+		want   string
+	}{
+		{"header", "/", "", "es-AR,en;q=0.5", Config{}, "es"},
+		{"query over header", "/?lang=en", "", "es", Config{}, "en"},
+		{"cookie over header", "/", "es", "en", Config{}, "es"},
+		{"unsupported query skipped", "/?lang=de", "", "es", Config{}, "es"},
+		{"default when nothing matches", "/", "", "fr", Config{}, "en"},
+		{"custom order", "/?lang=en", "", "es", Config{Order: []Source{SourceHeader, SourceQuery}}, "es"},
+		{"allow-list", "/", "", "es,en", Config{Languages: []string{"en"}}, "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			h := Middleware(catalog, tt.cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = catalog.GetMessageWithCtx(r.Context(), "greeting.hello", nil).Lang
+			}))
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set("Accept-Language", tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if got != tt.want {
+				t.Errorf("resolved language = %q, want %q", got, tt.want)
+			}
+			if cl := rec.Header().Get("Content-Language"); cl != tt.want {
+				t.Errorf("Content-Language = %q, want %q", cl, tt.want)
+			}
+			if vary := rec.Header().Get("Vary"); vary != "Accept-Language" {
+				t.Errorf("Vary = %q, want Accept-Language", vary)
+			}
+		})
+	}
+}
+
+func TestMiddleware_persistCookie(t *testing.T) {
+	catalog := newTestCatalog(t)
+	h := Middleware(catalog, Config{PersistCookie: true, CtxLanguageKey: "language"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.Context().Value(msgcat.ContextKey("language")); v != "es" {
+			t.Errorf("CtxLanguageKey value = %v, want es", v)
+		}
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?lang=es", nil))
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "lang" || cookies[0].Value != "es" {
+		t.Fatalf("expected lang=es cookie, got %v", cookies)
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/?lang=es", nil)
+	req.AddCookie(&http.Cookie{Name: "lang", Value: "es"})
+	h.ServeHTTP(rec, req)
+	if len(rec.Result().Cookies()) != 0 {
+		t.Errorf("cookie should not be rewritten when unchanged")
+	}
+}
+
+func TestNegotiate_catalogWithoutIntrospection(t *testing.T) {
+	// A decorator that only implements MessageCatalog: no Languages, no Localizer.
+	catalog := struct{ msgcat.MessageCatalog }{newTestCatalog(t)}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "es-AR,en;q=0.5")
+	if lang, source := Negotiate(catalog, req, Config{}); lang != "es-ar" || source != SourceHeader {
+		t.Errorf("Negotiate = %q, %v; want es-ar, SourceHeader", lang, source)
+	}
+	if lang, source := Negotiate(catalog, httptest.NewRequest(http.MethodGet, "/", nil), Config{}); lang != "" || source != SourceDefault {
+		t.Errorf("Negotiate without candidates = %q, %v; want empty, SourceDefault", lang, source)
+	}
+}
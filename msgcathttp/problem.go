@@ -0,0 +1,107 @@
+package msgcathttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/loopcontext/msgcat"
+)
+
+// ProblemContentType is the media type written by WriteError (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document built from a catalog error. Code and Key are
+// extension members; Cause is only set when ErrorWriter.Debug is true.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Cause    string `json:"cause,omitempty"`
+}
+
+// ErrorWriter renders errors as application/problem+json. The zero value is usable.
+type ErrorWriter struct {
+	Debug      bool                   // Include the wrapped cause (err.Unwrap()) as "cause". Keep false in production.
+	TypePrefix string                 // Prefix for "type"; the message key is appended. Default "urn:msgcat:".
+	StatusFunc func(msgcat.Error) int // Optional status mapping; default uses HTTPStatus(), then a numeric code, else 500.
+}
+
+// WriteError writes err as problem+json using a zero ErrorWriter (cause hidden).
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	ErrorWriter{}.WriteError(w, r, err)
+}
+
+// WriteError writes err as problem+json: title is the short message, detail the long message, and
+// Content-Language the error language. Errors that are not msgcat.Error are written as a generic 500.
+func (ew ErrorWriter) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := ew.Problem(r, err)
+	var catErr msgcat.Error
+	if errors.As(err, &catErr) && catErr.Lang() != "" {
+		w.Header().Set("Content-Language", catErr.Lang())
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// Problem builds the problem document for err without writing it (e.g. for custom encoders).
+func (ew ErrorWriter) Problem(r *http.Request, err error) Problem {
+	problem := Problem{Type: "about:blank"}
+	if r != nil && r.URL != nil {
+		problem.Instance = r.URL.Path
+	}
+	var catErr msgcat.Error
+	if !errors.As(err, &catErr) {
+		problem.Status = http.StatusInternalServerError
+		problem.Title = http.StatusText(problem.Status)
+		if ew.Debug && err != nil {
+			problem.Cause = err.Error()
+		}
+		return problem
+	}
+	typePrefix := ew.TypePrefix
+	if typePrefix == "" {
+		typePrefix = "urn:msgcat:"
+	}
+	problem.Type = typePrefix + catErr.ErrorKey()
+	problem.Title = catErr.GetShortMessage()
+	problem.Detail = catErr.GetLongMessage()
+	problem.Key = catErr.ErrorKey()
+	problem.Code = catErr.ErrorCode()
+	if problem.Code == "" {
+		problem.Code = catErr.ErrorKey()
+	}
+	problem.Status = ew.status(catErr)
+	if ew.Debug {
+		if cause := catErr.Unwrap(); cause != nil {
+			problem.Cause = cause.Error()
+		}
+	}
+	return problem
+}
+
+func (ew ErrorWriter) status(catErr msgcat.Error) int {
+	if ew.StatusFunc != nil {
+		if status := ew.StatusFunc(catErr); validStatus(status) {
+			return status
+		}
+	}
+	if status := catErr.HTTPStatus(); validStatus(status) {
+		return status
+	}
+	// Legacy catalogs overload code with HTTP statuses (code: 404).
+	if status, err := strconv.Atoi(catErr.ErrorCode()); err == nil && validStatus(status) {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+func validStatus(status int) bool {
+	return status >= 100 && status <= 599
+}
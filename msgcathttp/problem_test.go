@@ -0,0 +1,110 @@
+package msgcathttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/loopcontext/msgcat"
+)
+
+func TestWriteError_catalogError(t *testing.T) {
+	catalog := newTestCatalog(t)
+	if err := catalog.LoadMessages("es", []msgcat.RawMessage{{
+		Key:      "sys.not_found",
+		ShortTpl: "No encontrado",
+		LongTpl:  "El recurso {{id}} no existe",
+		Code:     msgcat.CodeInt(404),
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := msgcat.WithLanguage(t.Context(), "es")
+	err := catalog.WrapErrorWithCtx(ctx, errors.New("sql: no rows"), "sys.not_found", msgcat.Params{"id": 7})
+
+	rec := httptest.NewRecorder()
+	WriteError(rec, httptest.NewRequest(http.MethodGet, "/items/7", nil), err)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if cl := rec.Header().Get("Content-Language"); cl != "es" {
+		t.Errorf("Content-Language = %q, want es", cl)
+	}
+	var problem Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	want := Problem{
+		Type:     "urn:msgcat:sys.not_found",
+		Title:    "No encontrado",
+		Status:   404,
+		Detail:   "El recurso 7 no existe",
+		Instance: "/items/7",
+		Code:     "404",
+		Key:      "sys.not_found",
+	}
+	if problem != want {
+		t.Errorf("problem = %+v, want %+v", problem, want)
+	}
+}
+
+func TestErrorWriter_debugAndStatusFunc(t *testing.T) {
+	catalog := newTestCatalog(t)
+	err := catalog.WrapErrorWithCtx(t.Context(), errors.New("db timeout"), "greeting.hello", nil)
+	ew := ErrorWriter{
+		Debug:      true,
+		StatusFunc: func(msgcat.Error) int { return http.StatusServiceUnavailable },
+	}
+	problem := ew.Problem(nil, err)
+	if problem.Status != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", problem.Status)
+	}
+	if problem.Cause != "db timeout" {
+		t.Errorf("cause = %q, want db timeout", problem.Cause)
+	}
+	if problem.Code != "greeting.hello" {
+		t.Errorf("code should fall back to key, got %q", problem.Code)
+	}
+	if hidden := (ErrorWriter{}).Problem(nil, err); hidden.Cause != "" || hidden.Status != http.StatusInternalServerError {
+		t.Errorf("default writer should hide cause and use 500, got %+v", hidden)
+	}
+}
+
+func TestWriteError_plainError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), errors.New("boom"))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	var problem Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Cause != "" || problem.Detail != "" {
+		t.Errorf("plain error details must be hidden, got %+v", problem)
+	}
+}
+
+func TestErrorWriter_statusFromCatalogEntry(t *testing.T) {
+	catalog := newTestCatalog(t)
+	if err := catalog.LoadMessages("en", []msgcat.RawMessage{{
+		Key:      "sys.conflict",
+		ShortTpl: "Conflict",
+		Code:     msgcat.CodeString("ERR_CONFLICT"),
+		Status:   http.StatusConflict,
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	problem := ErrorWriter{}.Problem(nil, catalog.GetErrorWithCtx(t.Context(), "sys.conflict", nil))
+	if problem.Status != http.StatusConflict {
+		t.Errorf("status = %d, want 409", problem.Status)
+	}
+	if problem.Code != "ERR_CONFLICT" {
+		t.Errorf("code = %q, want ERR_CONFLICT", problem.Code)
+	}
+}
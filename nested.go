@@ -0,0 +1,144 @@
+package msgcat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// nestedMessages is Messages with set left generic, so nested entries can be flattened.
+type nestedMessages struct {
+	Group   OptionalGroup `yaml:"group,omitempty"`
+	Default RawMessage    `yaml:"default"`
+	Set     interface{}   `yaml:"set"`
+}
+
+// nestedLeafFields mark a map under set as a message entry rather than a level of nesting.
+var nestedLeafFields = []string{"short", "long", "short_forms", "long_forms"}
+
+// UnmarshalNestedMessages decodes a YAML catalog whose set may be nested, e.g.
+// greeting: {hello: {short: ..., long: ...}}, into messages with dotted keys ("greeting.hello").
+// A map is an entry when it has short, long, short_forms or long_forms (or is empty); otherwise its
+// keys are joined to the parent key with dots. Flat keys are accepted at any level. nested reports
+// whether any nesting was found.
+func UnmarshalNestedMessages(data []byte, messages *Messages) (nested bool, err error) {
+	var doc nestedMessages
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false, err
+	}
+	messages.Group = doc.Group
+	messages.Default = doc.Default
+	messages.Set = map[string]RawMessage{}
+	if doc.Set == nil {
+		return false, nil
+	}
+	set, ok := doc.Set.(map[interface{}]interface{})
+	if !ok {
+		return false, fmt.Errorf("set must be a map, got %T", doc.Set)
+	}
+	return flattenNested("", set, messages.Set)
+}
+
+func flattenNested(prefix string, node map[interface{}]interface{}, out map[string]RawMessage) (bool, error) {
+	nested := false
+	for rawKey, value := range node {
+		key := prefix + fmt.Sprint(rawKey)
+		child, isMap := value.(map[interface{}]interface{})
+		switch {
+		case value == nil:
+			out[key] = RawMessage{}
+		case !isMap:
+			return false, fmt.Errorf("invalid entry %q: expected a map, got %T", key, value)
+		case isNestedLeaf(child):
+			var raw RawMessage
+			data, err := yaml.Marshal(child)
+			if err == nil {
+				err = yaml.Unmarshal(data, &raw)
+			}
+			if err != nil {
+				return false, fmt.Errorf("invalid entry %q: %v", key, err)
+			}
+			if _, exists := out[key]; exists {
+				return false, fmt.Errorf("duplicate message key %q", key)
+			}
+			out[key] = raw
+		default:
+			nested = true
+			if _, err := flattenNested(key+".", child, out); err != nil {
+				return false, err
+			}
+		}
+	}
+	return nested, nil
+}
+
+func isNestedLeaf(node map[interface{}]interface{}) bool {
+	if len(node) == 0 {
+		return true
+	}
+	for _, field := range nestedLeafFields {
+		if _, found := node[field]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// nestedNode is one dot segment of a message key when marshalling nested YAML.
+type nestedNode struct {
+	entry    *RawMessage
+	children map[string]*nestedNode
+}
+
+// MarshalNestedMessages encodes messages as YAML with set keys nested by their dot segments (the inverse
+// of UnmarshalNestedMessages). When a key is both an entry and a prefix of other keys (e.g. "a" and
+// "a.b"), the longer keys are written dotted next to the entry.
+func MarshalNestedMessages(messages *Messages) ([]byte, error) {
+	root := &nestedNode{}
+	for key, raw := range messages.Set {
+		node := root
+		for _, segment := range strings.Split(key, ".") {
+			if node.children == nil {
+				node.children = map[string]*nestedNode{}
+			}
+			child, found := node.children[segment]
+			if !found {
+				child = &nestedNode{}
+				node.children[segment] = child
+			}
+			node = child
+		}
+		entry := raw
+		node.entry = &entry
+	}
+	doc := struct {
+		Group   OptionalGroup `yaml:"group,omitempty"`
+		Default RawMessage    `yaml:"default"`
+		Set     yaml.MapSlice `yaml:"set"`
+	}{Group: messages.Group, Default: messages.Default, Set: root.mapSlice("")}
+	return yaml.Marshal(&doc)
+}
+
+func (n *nestedNode) mapSlice(prefix string) yaml.MapSlice {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var out yaml.MapSlice
+	for _, name := range names {
+		child := n.children[name]
+		switch {
+		case child.entry == nil:
+			out = append(out, yaml.MapItem{Key: prefix + name, Value: child.mapSlice("")})
+		case len(child.children) == 0:
+			out = append(out, yaml.MapItem{Key: prefix + name, Value: child.entry})
+		default:
+			out = append(out, yaml.MapItem{Key: prefix + name, Value: child.entry})
+			out = append(out, child.mapSlice(prefix+name+".")...)
+		}
+	}
+	return out
+}
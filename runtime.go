@@ -0,0 +1,232 @@
+package msgcat
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// validateRuntimeMessage checks a message loaded from code: key required, RuntimeKeyPrefix, key format, status and max_length.
+func validateRuntimeMessage(op string, message RawMessage) error {
+	key := message.Key
+	if key == "" {
+		return fmt.Errorf("%s: message key is required", op)
+	}
+	if !strings.HasPrefix(key, RuntimeKeyPrefix) {
+		return fmt.Errorf("%s: key %q must have prefix %q", op, key, RuntimeKeyPrefix)
+	}
+	if !messageKeyRegex.MatchString(key) {
+		return fmt.Errorf("%s: invalid key %q", op, key)
+	}
+	if !validStatus(message.Status) {
+		return fmt.Errorf("%s: invalid status %d for key %q: must be between 100 and 599", op, message.Status, key)
+	}
+	if message.MaxLength < 0 {
+		return fmt.Errorf("%s: invalid max_length %d for key %q: must not be negative", op, message.MaxLength, key)
+	}
+	return nil
+}
+
+// runtimeMessage is the stored form of a message loaded from code (Key is the map key).
+func runtimeMessage(message RawMessage) RawMessage {
+	return RawMessage{
+		LongTpl:     message.LongTpl,
+		ShortTpl:    message.ShortTpl,
+		Code:        message.Code,
+		ShortForms:  copyForms(message.ShortForms),
+		LongForms:   copyForms(message.LongForms),
+		PluralParam: message.PluralParam,
+		Status:      message.Status,
+		Description: message.Description,
+		Context:     message.Context,
+		MaxLength:   message.MaxLength,
+	}
+}
+
+// runtimeSets returns the message set and runtime set for lang, creating both if needed. Callers hold dmc.mu.
+func (dmc *DefaultMessageCatalog) runtimeSets(lang string) (map[string]RawMessage, map[string]RawMessage) {
+	if dmc.messages == nil {
+		dmc.messages = map[string]Messages{}
+	}
+	if dmc.runtimeMessages == nil {
+		dmc.runtimeMessages = map[string]map[string]RawMessage{}
+	}
+	langMsgSet := dmc.messages[lang]
+	if langMsgSet.Set == nil {
+		langMsgSet.Set = map[string]RawMessage{}
+	}
+	dmc.messages[lang] = langMsgSet
+	if dmc.runtimeMessages[lang] == nil {
+		dmc.runtimeMessages[lang] = map[string]RawMessage{}
+	}
+	return langMsgSet.Set, dmc.runtimeMessages[lang]
+}
+
+// dropRuntimeKey removes a runtime message from lang, restoring the YAML entry it replaced, and drops
+// languages that only existed at runtime once they are empty. Callers hold dmc.mu.
+func (dmc *DefaultMessageCatalog) dropRuntimeKey(lang string, key string) {
+	runtimeSet := dmc.runtimeMessages[lang]
+	if _, found := runtimeSet[key]; !found {
+		return
+	}
+	delete(runtimeSet, key)
+	if len(runtimeSet) == 0 {
+		delete(dmc.runtimeMessages, lang)
+	}
+	langMsgSet, found := dmc.messages[lang]
+	if !found {
+		return
+	}
+	if yamlMsg, overridden := dmc.yamlOverridden[lang][key]; overridden {
+		langMsgSet.Set[key] = yamlMsg
+		delete(dmc.yamlOverridden[lang], key)
+		return
+	}
+	delete(langMsgSet.Set, key)
+	if len(langMsgSet.Set) == 0 && langMsgSet.Default.ShortTpl == "" && langMsgSet.Default.LongTpl == "" {
+		delete(dmc.messages, lang)
+	}
+}
+
+// loadRuntime validates every language and message of batch against the current sets (keys must not
+// exist yet) and applies them only when all are valid; the error joins one entry per invalid message.
+func (dmc *DefaultMessageCatalog) loadRuntime(op string, batch map[string][]RawMessage) error {
+	langs := make([]string, 0, len(batch))
+	for lang := range batch {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	dmc.mu.Lock()
+	defer dmc.mu.Unlock()
+
+	var errs []error
+	seen := map[string]map[string]struct{}{}
+	for _, lang := range langs {
+		normalizedLang := normalizeLangTag(lang)
+		if normalizedLang == "" {
+			errs = append(errs, fmt.Errorf("%s: language is required", op))
+			continue
+		}
+		if seen[normalizedLang] == nil {
+			seen[normalizedLang] = map[string]struct{}{}
+		}
+		existing := dmc.messages[normalizedLang].Set
+		for _, message := range batch[lang] {
+			if err := validateRuntimeMessage(op, message); err != nil {
+				errs = append(errs, fmt.Errorf("%w (language %s)", err, normalizedLang))
+				continue
+			}
+			_, foundMsg := existing[message.Key]
+			if _, foundInBatch := seen[normalizedLang][message.Key]; foundMsg || foundInBatch {
+				errs = append(errs, fmt.Errorf("message with key %q already exists in message set for language %s", message.Key, normalizedLang))
+				continue
+			}
+			seen[normalizedLang][message.Key] = struct{}{}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, lang := range langs {
+		if len(batch[lang]) == 0 {
+			continue
+		}
+		msgSet, runtimeSet := dmc.runtimeSets(normalizeLangTag(lang))
+		for _, message := range batch[lang] {
+			normalizedMessage := runtimeMessage(message)
+			msgSet[message.Key] = normalizedMessage
+			runtimeSet[message.Key] = normalizedMessage
+		}
+	}
+
+	return nil
+}
+
+// overrideYAML keeps the YAML entry replaced by a runtime message, so removing the runtime message
+// restores it. Callers hold dmc.mu.
+func (dmc *DefaultMessageCatalog) overrideYAML(lang string, key string, yamlMsg RawMessage) {
+	if dmc.yamlOverridden == nil {
+		dmc.yamlOverridden = map[string]map[string]RawMessage{}
+	}
+	if dmc.yamlOverridden[lang] == nil {
+		dmc.yamlOverridden[lang] = map[string]RawMessage{}
+	}
+	dmc.yamlOverridden[lang][key] = yamlMsg
+}
+
+// UpsertMessages adds or replaces runtime messages for a language. Keys follow the LoadMessages rules
+// (RuntimeKeyPrefix required); every message is validated before any is applied. A YAML entry with the
+// same key is overridden until the runtime message is removed.
+func (dmc *DefaultMessageCatalog) UpsertMessages(lang string, messages []RawMessage) error {
+	normalizedLang := normalizeLangTag(lang)
+	if normalizedLang == "" {
+		return fmt.Errorf("language is required")
+	}
+	for _, message := range messages {
+		if err := validateRuntimeMessage("UpsertMessages", message); err != nil {
+			return err
+		}
+	}
+
+	dmc.mu.Lock()
+	defer dmc.mu.Unlock()
+	msgSet, runtimeSet := dmc.runtimeSets(normalizedLang)
+	for _, message := range messages {
+		if yamlMsg, found := msgSet[message.Key]; found {
+			if _, isRuntime := runtimeSet[message.Key]; !isRuntime {
+				dmc.overrideYAML(normalizedLang, message.Key, yamlMsg)
+			}
+		}
+		normalizedMessage := runtimeMessage(message)
+		msgSet[message.Key] = normalizedMessage
+		runtimeSet[message.Key] = normalizedMessage
+	}
+
+	return nil
+}
+
+// RemoveMessages removes runtime messages for a language. Keys must have RuntimeKeyPrefix; keys that were
+// not loaded at runtime are ignored, so YAML entries are never removed, and a YAML entry overridden by
+// UpsertMessages is restored.
+func (dmc *DefaultMessageCatalog) RemoveMessages(lang string, keys ...string) error {
+	normalizedLang := normalizeLangTag(lang)
+	if normalizedLang == "" {
+		return fmt.Errorf("language is required")
+	}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, RuntimeKeyPrefix) {
+			return fmt.Errorf("RemoveMessages: key %q must have prefix %q", key, RuntimeKeyPrefix)
+		}
+	}
+
+	dmc.mu.Lock()
+	defer dmc.mu.Unlock()
+	for _, key := range keys {
+		dmc.dropRuntimeKey(normalizedLang, key)
+	}
+
+	return nil
+}
+
+// ClearRuntime removes every runtime message for a language, or for all languages when lang is empty.
+// Runtime messages otherwise survive Reload.
+func (dmc *DefaultMessageCatalog) ClearRuntime(lang string) {
+	dmc.mu.Lock()
+	defer dmc.mu.Unlock()
+
+	langs := []string{normalizeLangTag(lang)}
+	if langs[0] == "" {
+		langs = langs[:0]
+		for runtimeLang := range dmc.runtimeMessages {
+			langs = append(langs, runtimeLang)
+		}
+	}
+	for _, runtimeLang := range langs {
+		for key := range dmc.runtimeMessages[runtimeLang] {
+			dmc.dropRuntimeKey(runtimeLang, key)
+		}
+	}
+}
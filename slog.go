@@ -0,0 +1,163 @@
+package msgcat
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LogValue implements slog.LogValuer: a group with the key, code, resolved language and, when set,
+// status, fallback and missing flags.
+func (m Message) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("key", m.Key),
+		slog.String("code", m.Code),
+		slog.String("lang", m.Lang),
+	}
+	if m.Status != 0 {
+		attrs = append(attrs, slog.Int("status", m.Status))
+	}
+	if m.Fallback {
+		attrs = append(attrs, slog.Bool("fallback", true))
+	}
+	if m.Missing {
+		attrs = append(attrs, slog.Bool("missing", true))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer: a group with the short message, key, code, language, status
+// when set and, with Config.LogParams, the redacted params as a nested group.
+func (ce *DefaultError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("msg", ce.shortMessage),
+		slog.String("key", ce.key),
+		slog.String("code", ce.code),
+		slog.String("lang", ce.lang),
+	}
+	if ce.status != 0 {
+		attrs = append(attrs, slog.Int("status", ce.status))
+	}
+	if ce.opts.logParams && len(ce.params) > 0 {
+		redacted := ce.RedactedParams()
+		names := make([]string, 0, len(redacted))
+		for name := range redacted {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		params := make([]interface{}, 0, len(names))
+		for _, name := range names {
+			params = append(params, slog.Any(name, redacted[name]))
+		}
+		attrs = append(attrs, slog.Group("params", params...))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// slogObserverMaxKeys bounds the rate limiter state; it is cleared when exceeded.
+const slogObserverMaxKeys = 4096
+
+// SlogObserver is an Observer that logs catalog events with log/slog. Create it with NewSlogObserver
+// and adjust the exported fields before passing it in Config.Observer.
+type SlogObserver struct {
+	Logger               *slog.Logger
+	FallbackLevel        slog.Level    // Level for language fallbacks. Default Debug.
+	MissingLanguageLevel slog.Level    // Level for missing languages. Default Warn.
+	MissingMessageLevel  slog.Level    // Level for missing message keys. Default Warn.
+	TemplateIssueLevel   slog.Level    // Level for template issues. Default Warn.
+	RateLimit            time.Duration // Minimum interval between logs of the same event. Default 1m; zero or negative disables.
+	NowFn                func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*slogObserverEntry
+}
+
+type slogObserverEntry struct {
+	last       time.Time
+	suppressed int
+}
+
+// NewSlogObserver returns an Observer logging to logger (slog.Default() when nil) with default
+// levels and a one-minute rate limit per distinct event.
+func NewSlogObserver(logger *slog.Logger) *SlogObserver {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogObserver{
+		Logger:               logger,
+		FallbackLevel:        slog.LevelDebug,
+		MissingLanguageLevel: slog.LevelWarn,
+		MissingMessageLevel:  slog.LevelWarn,
+		TemplateIssueLevel:   slog.LevelWarn,
+		RateLimit:            time.Minute,
+		NowFn:                time.Now,
+		entries:              map[string]*slogObserverEntry{},
+	}
+}
+
+func (o *SlogObserver) OnLanguageFallback(requestedLang string, resolvedLang string) {
+	o.log(o.FallbackLevel, "msgcat: language fallback", "fallback:"+requestedLang+"->"+resolvedLang,
+		slog.String("requested_lang", requestedLang), slog.String("resolved_lang", resolvedLang))
+}
+
+func (o *SlogObserver) OnLanguageMissing(lang string) {
+	o.log(o.MissingLanguageLevel, "msgcat: language missing", "language:"+lang,
+		slog.String("lang", lang))
+}
+
+func (o *SlogObserver) OnMessageMissing(lang string, msgKey string) {
+	o.log(o.MissingMessageLevel, "msgcat: message missing", "message:"+lang+":"+msgKey,
+		slog.String("lang", lang), slog.String("key", msgKey))
+}
+
+func (o *SlogObserver) OnTemplateIssue(lang string, msgKey string, issue string) {
+	o.log(o.TemplateIssueLevel, "msgcat: template issue", "template:"+lang+":"+msgKey+":"+issue,
+		slog.String("lang", lang), slog.String("key", msgKey), slog.String("issue", issue))
+}
+
+func (o *SlogObserver) log(level slog.Level, msg string, eventKey string, attrs ...slog.Attr) {
+	ctx := context.Background()
+	if !o.Logger.Enabled(ctx, level) {
+		return
+	}
+	suppressed, ok := o.allow(eventKey)
+	if !ok {
+		return
+	}
+	if suppressed > 0 {
+		attrs = append(attrs, slog.Int("suppressed", suppressed))
+	}
+	o.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// allow reports whether eventKey may be logged now and how many occurrences were suppressed since
+// it was last logged.
+func (o *SlogObserver) allow(eventKey string) (int, bool) {
+	if o.RateLimit <= 0 {
+		return 0, true
+	}
+	now := time.Now()
+	if o.NowFn != nil {
+		now = o.NowFn()
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.entries == nil || len(o.entries) >= slogObserverMaxKeys {
+		o.entries = map[string]*slogObserverEntry{}
+	}
+	entry, found := o.entries[eventKey]
+	if !found {
+		o.entries[eventKey] = &slogObserverEntry{last: now}
+		return 0, true
+	}
+	if now.Sub(entry.last) < o.RateLimit {
+		entry.suppressed++
+		return 0, false
+	}
+	suppressed := entry.suppressed
+	entry.last = now
+	entry.suppressed = 0
+	return suppressed, true
+}
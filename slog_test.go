@@ -0,0 +1,91 @@
+package msgcat_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/loopcontext/msgcat"
+)
+
+func TestDefaultError_LogValue(t *testing.T) {
+	dir := t.TempDir()
+	en := []byte("default:\n  short: Err\n  long: Err\nset:\n  error.invalid_field:\n    code: ERR_FIELD\n    status: 422\n    short: Invalid {{field}}\n    long: Field {{field}} is invalid\n")
+	if err := os.WriteFile(filepath.Join(dir, "en.yaml"), en, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	catalog, err := msgcat.NewMessageCatalog(msgcat.Config{
+		ResourcePath:    dir,
+		LogParams:       true,
+		SensitiveParams: []string{"value"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := msgcat.WithLanguage(context.Background(), "en")
+	catErr := catalog.GetErrorWithCtx(ctx, "error.invalid_field", msgcat.Params{"field": "email", "value": "a@b.c"})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("request failed", "err", catErr, "msg_info", catalog.GetMessageWithCtx(ctx, "error.invalid_field", nil))
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("decode log: %v (%s)", err, buf.String())
+	}
+	errGroup, ok := record["err"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("err should be a group, got %v", record["err"])
+	}
+	if errGroup["key"] != "error.invalid_field" || errGroup["code"] != "ERR_FIELD" || errGroup["lang"] != "en" {
+		t.Errorf("unexpected err group: %v", errGroup)
+	}
+	if errGroup["status"] != float64(422) {
+		t.Errorf("status = %v, want 422", errGroup["status"])
+	}
+	params, ok := errGroup["params"].(map[string]interface{})
+	if !ok || params["field"] != "email" || params["value"] != msgcat.RedactedParamValue {
+		t.Errorf("params should be logged redacted, got %v", errGroup["params"])
+	}
+	msgGroup, ok := record["msg_info"].(map[string]interface{})
+	if !ok || msgGroup["key"] != "error.invalid_field" || msgGroup["lang"] != "en" {
+		t.Errorf("unexpected message group: %v", record["msg_info"])
+	}
+}
+
+func TestSlogObserver_levelsAndRateLimit(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	observer := msgcat.NewSlogObserver(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	observer.NowFn = func() time.Time { return now }
+
+	observer.OnLanguageFallback("es-mx", "es") // Debug: filtered by handler level
+	observer.OnMessageMissing("en", "missing.key")
+	observer.OnMessageMissing("en", "missing.key") // rate limited
+	observer.OnMessageMissing("en", "missing.key") // rate limited
+	observer.OnTemplateIssue("en", "greeting", "simple_missing_param_name")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "level=WARN") || !strings.Contains(lines[0], "key=missing.key") {
+		t.Errorf("unexpected missing message log: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "issue=simple_missing_param_name") {
+		t.Errorf("unexpected template issue log: %s", lines[1])
+	}
+
+	buf.Reset()
+	now = now.Add(2 * time.Minute)
+	observer.OnMessageMissing("en", "missing.key")
+	if !strings.Contains(buf.String(), "suppressed=2") {
+		t.Errorf("expected suppressed count after rate limit window, got %s", buf.String())
+	}
+}
@@ -17,24 +17,38 @@ type Messages struct {
 // it is for projects that map their own error/message codes into the catalog. Uniqueness is not enforced.
 // Optional ShortForms/LongForms enable CLDR plural forms (zero, one, two, few, many, other); when set,
 // the plural_param (default "count") is used to select the form. See docs/CLDR_AND_GO_MESSAGES_PLAN.md.
+// Status is the optional HTTP status for the entry, so Code can stay a business code.
 type RawMessage struct {
 	LongTpl     string            `yaml:"long"`
 	ShortTpl    string            `yaml:"short"`
-	Code        OptionalCode      `yaml:"code"` // Optional. In YAML: code: 404 or code: "ERR_NOT_FOUND". Use Key when empty.
-	ShortForms  map[string]string  `yaml:"short_forms,omitempty"` // Optional CLDR forms: zero, one, two, few, many, other.
-	LongForms   map[string]string  `yaml:"long_forms,omitempty"`
+	Code        OptionalCode      `yaml:"code"`                  // Optional. In YAML: code: 404 or code: "ERR_NOT_FOUND". Use Key when empty.
+	ShortForms  map[string]string `yaml:"short_forms,omitempty"` // Optional CLDR forms: zero, one, two, few, many, other.
+	LongForms   map[string]string `yaml:"long_forms,omitempty"`
 	PluralParam string            `yaml:"plural_param,omitempty"` // Param name for plural selection (default "count").
+	Status      int               `yaml:"status,omitempty"`       // Optional HTTP status (100-599) for transports; 0 when not set.
+	State       string            `yaml:"state,omitempty"`        // Optional translation state (new, translated, reviewed) kept by CLI import/export; not used at runtime.
+	Description string            `yaml:"description,omitempty"`  // Optional note for translators (what the text is, where it is shown); not used at runtime.
+	Context     string            `yaml:"context,omitempty"`      // Optional context (e.g. "Button label on checkout"); not used at runtime.
+	MaxLength   int               `yaml:"max_length,omitempty"`   // Optional maximum length of the short text in characters; checked by msgcat validate.
 	// Key is set when loading via LoadMessages (runtime); YAML uses the map key as the message key.
 	Key string `yaml:"-"`
 }
 
 // Message is the resolved message for a request. Key is always the message key used for lookup.
 // Code is optional (from catalog); when empty, use Key as the API identifier (e.g. in JSON responses).
+// Lang is the catalog language that produced the text (e.g. for a Content-Language header); it is empty
+// when no language in the fallback chain is loaded. The JSON encoding is
+// {"key","code","short","long","lang"} plus requested_lang, fallback, missing and status when set.
 type Message struct {
-	LongText  string
-	ShortText string
-	Code      string // Optional; user-defined (e.g. "404", "ERR_001"). Empty when not set. Use Key when empty.
-	Key       string // Message key (e.g. "greeting.hello"); set when found or when missing (requested key).
+	LongText      string `json:"long"`
+	ShortText     string `json:"short"`
+	Code          string `json:"code"`                     // Optional; user-defined (e.g. "404", "ERR_001"). Empty when not set. Use Key when empty.
+	Key           string `json:"key"`                      // Message key (e.g. "greeting.hello"); set when found or when missing (requested key).
+	Lang          string `json:"lang"`                     // Resolved language (e.g. "es"); empty when the language is missing.
+	RequestedLang string `json:"requested_lang,omitempty"` // Normalized language from context (e.g. "es-ar").
+	Fallback      bool   `json:"fallback,omitempty"`       // True when Lang differs from RequestedLang (fallback chain was used).
+	Missing       bool   `json:"missing,omitempty"`        // True when the key or language was not found and default/placeholder text was used.
+	Status        int    `json:"status,omitempty"`         // Optional HTTP status from the catalog entry; 0 when not set.
 }
 
 // MessageDef defines a message that can be extracted to YAML via the msgcat CLI (extract -source).
@@ -43,10 +57,14 @@ type MessageDef struct {
 	Key         string            // Message key (e.g. "person.cats"). Required.
 	Short       string            // Short template (or use ShortForms for CLDR).
 	Long        string            // Long template (or use LongForms for CLDR).
-	ShortForms  map[string]string  `yaml:"short_forms,omitempty"` // Optional CLDR forms: zero, one, two, few, many, other.
-	LongForms   map[string]string  `yaml:"long_forms,omitempty"`
+	ShortForms  map[string]string `yaml:"short_forms,omitempty"` // Optional CLDR forms: zero, one, two, few, many, other.
+	LongForms   map[string]string `yaml:"long_forms,omitempty"`
 	PluralParam string            `yaml:"plural_param,omitempty"` // Param name for plural selection (default "count").
 	Code        OptionalCode      `yaml:"code,omitempty"`
+	Status      int               `yaml:"status,omitempty"`      // Optional HTTP status (100-599).
+	Description string            `yaml:"description,omitempty"` // Optional note for translators.
+	Context     string            `yaml:"context,omitempty"`     // Optional context (e.g. "Button label on checkout").
+	MaxLength   int               `yaml:"max_length,omitempty"`  // Optional maximum length of the short text.
 }
 
 type MessageCatalogStats struct {
@@ -77,4 +95,8 @@ type Config struct {
 	ReloadRetries     int
 	ReloadRetryDelay  time.Duration
 	NowFn             func() time.Time
+	SensitiveParams   []string // Param names masked by DefaultError.RedactedParams (e.g. "password", "email").
+	LogParams         bool     // Include (redacted) params when errors are logged with log/slog.
+	NestedKeys        bool     // Flatten nested set entries (greeting: {hello: {short: ...}}) into dotted keys.
+	SplitFiles        bool     // Merge <lang>.<name>.<ext> files and <lang>/ directories into the <lang> catalog.
 }
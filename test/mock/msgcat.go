@@ -48,6 +48,65 @@ func (mr *MockMessageCatalogMockRecorder) LoadMessages(lang, messages interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadMessages", reflect.TypeOf((*MockMessageCatalog)(nil).LoadMessages), lang, messages)
 }
 
+// LoadBatch mocks base method
+func (m *MockMessageCatalog) LoadBatch(batch map[string][]msgcat.RawMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBatch", batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadBatch indicates an expected call of LoadBatch
+func (mr *MockMessageCatalogMockRecorder) LoadBatch(batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBatch", reflect.TypeOf((*MockMessageCatalog)(nil).LoadBatch), batch)
+}
+
+// UpsertMessages mocks base method
+func (m *MockMessageCatalog) UpsertMessages(lang string, messages []msgcat.RawMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMessages", lang, messages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertMessages indicates an expected call of UpsertMessages
+func (mr *MockMessageCatalogMockRecorder) UpsertMessages(lang, messages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMessages", reflect.TypeOf((*MockMessageCatalog)(nil).UpsertMessages), lang, messages)
+}
+
+// RemoveMessages mocks base method
+func (m *MockMessageCatalog) RemoveMessages(lang string, keys ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{lang}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveMessages", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMessages indicates an expected call of RemoveMessages
+func (mr *MockMessageCatalogMockRecorder) RemoveMessages(lang interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{lang}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMessages", reflect.TypeOf((*MockMessageCatalog)(nil).RemoveMessages), varargs...)
+}
+
+// ClearRuntime mocks base method
+func (m *MockMessageCatalog) ClearRuntime(lang string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearRuntime", lang)
+}
+
+// ClearRuntime indicates an expected call of ClearRuntime
+func (mr *MockMessageCatalogMockRecorder) ClearRuntime(lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearRuntime", reflect.TypeOf((*MockMessageCatalog)(nil).ClearRuntime), lang)
+}
+
 // GetMessageWithCtx mocks base method
 func (m *MockMessageCatalog) GetMessageWithCtx(ctx context.Context, msgKey string, params msgcat.Params) *msgcat.Message {
 	m.ctrl.T.Helper()
@@ -89,3 +148,17 @@ func (mr *MockMessageCatalogMockRecorder) GetErrorWithCtx(ctx, msgKey, params in
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErrorWithCtx", reflect.TypeOf((*MockMessageCatalog)(nil).GetErrorWithCtx), ctx, msgKey, params)
 }
+
+// Localize mocks base method
+func (m *MockMessageCatalog) Localize(ctx context.Context, err error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Localize", ctx, err)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Localize indicates an expected call of Localize
+func (mr *MockMessageCatalogMockRecorder) Localize(ctx, err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Localize", reflect.TypeOf((*MockMessageCatalog)(nil).Localize), ctx, err)
+}
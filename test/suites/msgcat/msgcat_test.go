@@ -2,6 +2,7 @@ package test_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -153,12 +154,82 @@ var _ = Describe("Message Catalog", func() {
 		Expect(message.ShortText).To(Equal("Hola, breve descripción"))
 	})
 
+	It("should read language set with typed context helpers", func() {
+		langCtx := msgcat.WithLanguage(context.Background(), "ES")
+		lang, ok := msgcat.LanguageFromContext(langCtx)
+		Expect(ok).To(BeTrue())
+		Expect(lang).To(Equal("es"))
+		Expect(messageCatalog.GetMessageWithCtx(langCtx, "greeting.hello", nil).ShortText).To(Equal("Hola, breve descripción"))
+
+		_, ok = msgcat.LanguageFromContext(context.Background())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should prefer typed context helpers over CtxLanguageKey", func() {
+		ctx.SetValue("language", "en")
+		langCtx := msgcat.WithLanguage(ctx.Ctx, "es")
+		Expect(messageCatalog.GetMessageWithCtx(langCtx, "greeting.hello", nil).Lang).To(Equal("es"))
+	})
+
+	It("should use the first loaded language from a context preference list", func() {
+		langCtx := msgcat.WithLanguages(context.Background(), "de-DE", "", "es-MX", "en")
+		langs, ok := msgcat.LanguagesFromContext(langCtx)
+		Expect(ok).To(BeTrue())
+		Expect(langs).To(Equal([]string{"de-de", "es-mx", "en"}))
+
+		message := messageCatalog.GetMessageWithCtx(langCtx, "greeting.hello", nil)
+		Expect(message.ShortText).To(Equal("Hola, breve descripción"))
+		Expect(message.RequestedLang).To(Equal("de-de"))
+		Expect(message.Fallback).To(BeTrue())
+	})
+
 	It("should fallback from regional language to base language", func() {
 		ctx.SetValue("language", "es-AR")
 		message := messageCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil)
 		Expect(message.ShortText).To(Equal("Hola, breve descripción"))
 	})
 
+	It("should expose resolved language and fallback details on messages", func() {
+		ctx.SetValue("language", "es-AR")
+		message := messageCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil)
+		Expect(message.Lang).To(Equal("es"))
+		Expect(message.RequestedLang).To(Equal("es-ar"))
+		Expect(message.Fallback).To(BeTrue())
+		Expect(message.Missing).To(BeFalse())
+
+		ctx.SetValue("language", "es")
+		missing := messageCatalog.GetMessageWithCtx(ctx.Ctx, "missing.key", nil)
+		Expect(missing.Lang).To(Equal("es"))
+		Expect(missing.Fallback).To(BeFalse())
+		Expect(missing.Missing).To(BeTrue())
+	})
+
+	It("should expose resolved language and fallback details on errors", func() {
+		ctx.SetValue("language", "es-MX")
+		err := messageCatalog.GetErrorWithCtx(ctx.Ctx, "greeting.hello", nil)
+		castedError := err.(msgcat.Error)
+		Expect(castedError.Lang()).To(Equal("es"))
+		Expect(castedError.RequestedLang()).To(Equal("es-mx"))
+		Expect(castedError.IsFallback()).To(BeTrue())
+		Expect(castedError.IsMissing()).To(BeFalse())
+	})
+
+	It("should flag missing language with empty resolved language", func() {
+		tmpDir, err := os.MkdirTemp("", "msgcat-empty-lang-*")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+
+		emptyCatalog, err := msgcat.NewMessageCatalog(msgcat.Config{ResourcePath: tmpDir})
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx.SetValue("language", "de")
+		message := emptyCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil)
+		Expect(message.Code).To(Equal(msgcat.CodeMissingLanguage))
+		Expect(message.Lang).To(Equal(""))
+		Expect(message.RequestedLang).To(Equal("de"))
+		Expect(message.Missing).To(BeTrue())
+	})
+
 	It("should return error with correct message", func() {
 		ctx.SetValue("language", "es")
 		err := messageCatalog.GetErrorWithCtx(ctx.Ctx, "greeting.hello", nil)
@@ -174,6 +245,60 @@ var _ = Describe("Message Catalog", func() {
 		Expect(castedError.ErrorCode()).To(Equal("GREETING_HELLO"))
 	})
 
+	It("should expose HTTP status from catalog entries", func() {
+		message := messageCatalog.GetMessageWithCtx(ctx.Ctx, "error.not_found", nil)
+		Expect(message.Status).To(Equal(404))
+		Expect(message.Code).To(Equal("ERR_NOT_FOUND"))
+		err := messageCatalog.GetErrorWithCtx(ctx.Ctx, "error.not_found", nil)
+		Expect(err.(msgcat.Error).HTTPStatus()).To(Equal(404))
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil).Status).To(Equal(0))
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "missing.key", nil).Status).To(Equal(0))
+	})
+
+	It("should validate HTTP status range", func() {
+		tmpDir, err := os.MkdirTemp("", "msgcat-status-*")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+
+		content := []byte("default:\n  short: Err\n  long: Err\nset:\n  bad.status:\n    short: Bad\n    status: 700\n")
+		err = os.WriteFile(filepath.Join(tmpDir, "en.yaml"), content, 0o600)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = msgcat.NewMessageCatalog(msgcat.Config{ResourcePath: tmpDir})
+		Expect(err).To(MatchError(ContainSubstring("invalid status 700")))
+
+		err = messageCatalog.LoadMessages("en", []msgcat.RawMessage{{Key: "sys.bad_status", ShortTpl: "Bad", Status: 42}})
+		Expect(err).To(HaveOccurred())
+		err = messageCatalog.LoadMessages("en", []msgcat.RawMessage{{Key: "sys.maintenance", ShortTpl: "Down", Status: 503}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.maintenance", nil).Status).To(Equal(503))
+	})
+
+	It("should keep translator notes and reject negative max_length", func() {
+		tmpDir, err := os.MkdirTemp("", "msgcat-notes-*")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+
+		content := []byte("default:\n  short: Err\n  long: Err\nset:\n  checkout.pay:\n    short: Pay\n    description: Button label\n    context: Checkout page\n    max_length: 20\n")
+		Expect(os.WriteFile(filepath.Join(tmpDir, "en.yaml"), content, 0o600)).To(Succeed())
+		catalog, err := msgcat.NewMessageCatalog(msgcat.Config{ResourcePath: tmpDir})
+		Expect(err).NotTo(HaveOccurred())
+		entry, ok, err := msgcat.Entry(catalog, "en", "checkout.pay")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(entry.Description).To(Equal("Button label"))
+		Expect(entry.Context).To(Equal("Checkout page"))
+		Expect(entry.MaxLength).To(Equal(20))
+		Expect(catalog.GetMessageWithCtx(ctx.Ctx, "checkout.pay", nil).ShortText).To(Equal("Pay"))
+
+		content = []byte("default:\n  short: Err\n  long: Err\nset:\n  checkout.pay:\n    short: Pay\n    max_length: -1\n")
+		Expect(os.WriteFile(filepath.Join(tmpDir, "en.yaml"), content, 0o600)).To(Succeed())
+		_, err = msgcat.NewMessageCatalog(msgcat.Config{ResourcePath: tmpDir})
+		Expect(err).To(MatchError(ContainSubstring("invalid max_length -1")))
+
+		err = messageCatalog.LoadMessages("en", []msgcat.RawMessage{{Key: "sys.label", ShortTpl: "OK", MaxLength: -5}})
+		Expect(err).To(MatchError(ContainSubstring("invalid max_length -5")))
+	})
+
 	It("should be able to load messages from code", func() {
 		err := messageCatalog.LoadMessages("en", []msgcat.RawMessage{{
 			Key:      "sys.9001",
@@ -239,6 +364,180 @@ var _ = Describe("Message Catalog", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("should load batches across languages all or nothing", func() {
+		err := messageCatalog.LoadBatch(map[string][]msgcat.RawMessage{
+			"en": {{Key: "sys.ok", ShortTpl: "OK"}, {Key: "app.bad", ShortTpl: "Bad prefix"}},
+			"es": {{Key: "sys.ok", ShortTpl: "Bien"}, {Key: "sys.ok", ShortTpl: "Duplicado"}, {Key: "sys.status", Status: 999}},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`key "app.bad" must have prefix "sys."`))
+		Expect(err.Error()).To(ContainSubstring(`key "sys.ok" already exists in message set for language es`))
+		Expect(err.Error()).To(ContainSubstring(`invalid status 999 for key "sys.status"`))
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.ok", nil).Missing).To(BeTrue())
+
+		err = messageCatalog.LoadBatch(map[string][]msgcat.RawMessage{
+			"en": {{Key: "sys.ok", ShortTpl: "OK"}},
+			"es": {{Key: "sys.ok", ShortTpl: "Bien"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.ok", nil).ShortText).To(Equal("OK"))
+		ctx.SetValue("language", "es")
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.ok", nil).ShortText).To(Equal("Bien"))
+	})
+
+	It("should not leave partial state when LoadMessages fails", func() {
+		err := messageCatalog.LoadMessages("en", []msgcat.RawMessage{
+			{Key: "sys.first", ShortTpl: "First"},
+			{Key: "bad", ShortTpl: "Bad"},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.first", nil).Missing).To(BeTrue())
+	})
+
+	It("should upsert runtime messages atomically", func() {
+		err := messageCatalog.LoadMessages("en", []msgcat.RawMessage{{Key: "sys.flag", ShortTpl: "Flag v1"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = messageCatalog.UpsertMessages("en", []msgcat.RawMessage{
+			{Key: "sys.flag", ShortTpl: "Flag v2"},
+			{Key: "sys.banner", ShortTpl: "Banner"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.flag", nil).ShortText).To(Equal("Flag v2"))
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.banner", nil).ShortText).To(Equal("Banner"))
+
+		err = messageCatalog.UpsertMessages("en", []msgcat.RawMessage{
+			{Key: "sys.flag", ShortTpl: "Flag v3"},
+			{Key: "app.flag", ShortTpl: "Not allowed"},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.flag", nil).ShortText).To(Equal("Flag v2"))
+	})
+
+	It("should remove and clear runtime messages but keep YAML entries", func() {
+		err := messageCatalog.UpsertMessages("en", []msgcat.RawMessage{
+			{Key: "sys.a", ShortTpl: "A"},
+			{Key: "sys.b", ShortTpl: "B"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		err = messageCatalog.UpsertMessages("pt", []msgcat.RawMessage{{Key: "sys.a", ShortTpl: "A pt"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(messageCatalog.RemoveMessages("en", "greeting.hello")).To(HaveOccurred())
+		Expect(messageCatalog.RemoveMessages("en", "sys.a", "sys.unknown")).To(Succeed())
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.a", nil).Missing).To(BeTrue())
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.b", nil).ShortText).To(Equal("B"))
+
+		messageCatalog.ClearRuntime("en")
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.b", nil).Missing).To(BeTrue())
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil).Missing).To(BeFalse())
+
+		langs, err := msgcat.Languages(messageCatalog)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(langs).To(ContainElement("pt"))
+		messageCatalog.ClearRuntime("")
+		langs, _ = msgcat.Languages(messageCatalog)
+		Expect(langs).To(Equal([]string{"en", "es"}))
+
+		Expect(msgcat.Reload(messageCatalog)).To(Succeed())
+		Expect(messageCatalog.GetMessageWithCtx(ctx.Ctx, "sys.b", nil).Missing).To(BeTrue())
+	})
+
+	It("should restore a YAML entry overridden by UpsertMessages when it is removed", func() {
+		tmpDir, err := os.MkdirTemp("", "msgcat-override-*")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+		content := "default:\n  short: Unexpected error\nset:\n  sys.notice:\n    short: From YAML\n"
+		Expect(os.WriteFile(filepath.Join(tmpDir, "en.yaml"), []byte(content), 0o600)).To(Succeed())
+
+		customCatalog, err := msgcat.NewMessageCatalog(msgcat.Config{ResourcePath: tmpDir})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(customCatalog.UpsertMessages("en", []msgcat.RawMessage{{Key: "sys.notice", ShortTpl: "From code"}})).To(Succeed())
+		Expect(msgcat.Reload(customCatalog)).To(Succeed())
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "sys.notice", nil).ShortText).To(Equal("From code"))
+
+		Expect(customCatalog.RemoveMessages("en", "sys.notice")).To(Succeed())
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "sys.notice", nil).ShortText).To(Equal("From YAML"))
+
+		Expect(customCatalog.UpsertMessages("en", []msgcat.RawMessage{{Key: "sys.notice", ShortTpl: "From code"}})).To(Succeed())
+		customCatalog.ClearRuntime("en")
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "sys.notice", nil).ShortText).To(Equal("From YAML"))
+	})
+
+	It("should introspect loaded languages, keys and entries", func() {
+		err := messageCatalog.LoadMessages("pt", []msgcat.RawMessage{{
+			Key:      "sys.ready",
+			ShortTpl: "Pronto",
+			LongTpl:  "Sistema pronto",
+		}})
+		Expect(err).NotTo(HaveOccurred())
+
+		langs, err := msgcat.Languages(messageCatalog)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(langs).To(Equal([]string{"en", "es", "pt"}))
+
+		keys, err := msgcat.Keys(messageCatalog, "pt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(Equal([]string{"sys.ready"}))
+		keys, err = msgcat.Keys(messageCatalog, "ES")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(ContainElements("greeting.hello", "items.count"))
+		keys, err = msgcat.Keys(messageCatalog, "fr")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(BeEmpty())
+
+		has, err := msgcat.Has(messageCatalog, "en", "error.not_found")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has).To(BeTrue())
+		has, _ = msgcat.Has(messageCatalog, "es", "error.not_found")
+		Expect(has).To(BeFalse())
+
+		entry, found, err := msgcat.Entry(messageCatalog, "en", "items.count")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(entry.Key).To(Equal("items.count"))
+		entry.ShortForms = map[string]string{"other": "mutated"}
+		entry.ShortTpl = "mutated"
+		again, _, _ := msgcat.Entry(messageCatalog, "en", "items.count")
+		Expect(again.ShortTpl).NotTo(Equal("mutated"))
+
+		_, found, err = msgcat.Entry(messageCatalog, "en", "missing.key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("should render messages and errors through a language-bound localizer", func() {
+		localizer, err := msgcat.NewLocalizer(messageCatalog, "es-AR")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(localizer.Lang()).To(Equal("es"))
+
+		message := localizer.Message("greeting.hello", nil)
+		Expect(message.ShortText).To(Equal("Hola, breve descripción"))
+		Expect(message.RequestedLang).To(Equal("es-ar"))
+		Expect(message.Fallback).To(BeTrue())
+
+		inner := errors.New("smtp timeout")
+		err = localizer.Wrap(inner, "greeting.hello", nil)
+		Expect(err.Error()).To(Equal("Hola, breve descripción"))
+		Expect(errors.Is(err, inner)).To(BeTrue())
+		Expect(localizer.Error("greeting.hello", nil).(msgcat.Error).Lang()).To(Equal("es"))
+	})
+
+	It("should pick the first loaded language from a localizer preference list", func() {
+		localizer, err := msgcat.NewLocalizer(messageCatalog, "de", "es")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(localizer.Lang()).To(Equal("es"))
+		Expect(localizer.Message("greeting.hello", nil).ShortText).To(Equal("Hola, breve descripción"))
+
+		defaultLocalizer, err := msgcat.NewLocalizer(messageCatalog)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(defaultLocalizer.Lang()).To(Equal("en"))
+		decorated := struct{ msgcat.MessageCatalog }{messageCatalog}
+		_, err = msgcat.NewLocalizer(decorated)
+		Expect(err).To(MatchError("catalog does not support localizers"))
+		Expect(defaultLocalizer.Message("greeting.hello", nil).Fallback).To(BeFalse())
+	})
+
 	It("should wrap error", func() {
 		err := errors.New("original error")
 		ctErr := messageCatalog.WrapErrorWithCtx(ctx.Ctx, err, "greeting.hello", nil)
@@ -246,6 +545,155 @@ var _ = Describe("Message Catalog", func() {
 		Expect(errors.Unwrap(ctErr)).To(Equal(err))
 	})
 
+	It("should match catalog errors by key with errors.Is", func() {
+		inner := errors.New("sql: no rows")
+		ctErr := messageCatalog.WrapErrorWithCtx(ctx.Ctx, inner, "error.not_found", nil)
+		wrapped := fmt.Errorf("load item: %w", ctErr)
+		Expect(errors.Is(wrapped, msgcat.KeyError("error.not_found"))).To(BeTrue())
+		Expect(errors.Is(wrapped, msgcat.KeyError("greeting.hello"))).To(BeFalse())
+		Expect(errors.Is(wrapped, inner)).To(BeTrue())
+
+		ctx.SetValue("language", "es")
+		esErr := messageCatalog.GetErrorWithCtx(ctx.Ctx, "greeting.hello", msgcat.Params{"name": "x"})
+		Expect(errors.Is(esErr, msgcat.KeyError("greeting.hello"))).To(BeTrue())
+	})
+
+	It("should find keys in wrapped and joined error trees with HasKey", func() {
+		notFound := messageCatalog.GetErrorWithCtx(ctx.Ctx, "error.not_found", nil)
+		hello := messageCatalog.GetErrorWithCtx(ctx.Ctx, "greeting.hello", nil)
+		joined := fmt.Errorf("batch: %w", errors.Join(errors.New("plain"), fmt.Errorf("item 2: %w", notFound), hello))
+		Expect(msgcat.HasKey(joined, "error.not_found")).To(BeTrue())
+		Expect(msgcat.HasKey(joined, "greeting.hello")).To(BeTrue())
+		Expect(msgcat.HasKey(joined, "dup.a")).To(BeFalse())
+		Expect(msgcat.HasKey(nil, "error.not_found")).To(BeFalse())
+	})
+
+	It("should defer rendering of errors created with NewError until Localize", func() {
+		inner := errors.New("sql: no rows")
+		pending := msgcat.NewError("greeting.template", msgcat.Params{"name": "Ana", "detail": "X"}, inner)
+		Expect(pending.Error()).To(Equal("greeting.template"))
+		Expect(errors.Is(pending, msgcat.KeyError("greeting.template"))).To(BeTrue())
+
+		localized := messageCatalog.Localize(ctx.Ctx, pending)
+		var catErr msgcat.Error
+		Expect(errors.As(localized, &catErr)).To(BeTrue())
+		Expect(catErr.GetShortMessage()).To(Equal("Hello template Ana, this is nice X"))
+		Expect(catErr.Lang()).To(Equal("en"))
+		Expect(errors.Is(localized, inner)).To(BeTrue())
+		Expect(messageCatalog.Localize(ctx.Ctx, nil)).To(BeNil())
+	})
+
+	It("should localize every catalog error in wrapped and joined chains", func() {
+		deep := msgcat.NewError("greeting.hello", nil, nil)
+		chain := fmt.Errorf("handler: %w", errors.Join(
+			fmt.Errorf("item 1: %w", deep),
+			msgcat.NewError("dup.a", nil, nil),
+			errors.New("plain"),
+		))
+
+		ctx.SetValue("language", "es")
+		localized := messageCatalog.Localize(ctx.Ctx, chain)
+		Expect(localized.Error()).To(ContainSubstring("handler: item 1: Hola, breve descripción"))
+		Expect(localized.Error()).To(ContainSubstring("Primera con código compartido"))
+		Expect(localized.Error()).To(ContainSubstring("plain"))
+		Expect(msgcat.HasKey(localized, "dup.a")).To(BeTrue())
+
+		enErr := messageCatalog.GetErrorWithCtx(context.Background(), "greeting.hello", nil)
+		esLocalizer, err := msgcat.NewLocalizer(messageCatalog, "es")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(esLocalizer.Localize(enErr).Error()).To(Equal("Hola, breve descripción"))
+
+		plain := errors.New("untouched")
+		Expect(messageCatalog.Localize(ctx.Ctx, plain)).To(BeIdenticalTo(plain))
+	})
+
+	It("should preserve params on catalog errors and redact sensitive keys", func() {
+		sensitiveCatalog, err := msgcat.NewMessageCatalog(msgcat.Config{
+			ResourcePath:    "./resources/messages",
+			SensitiveParams: []string{"detail"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		params := msgcat.Params{"name": "email", "detail": "secret@example.com"}
+		ctErr := sensitiveCatalog.GetErrorWithCtx(ctx.Ctx, "greeting.template", params)
+		params["name"] = "mutated"
+
+		var catErr *msgcat.DefaultError
+		Expect(errors.As(ctErr, &catErr)).To(BeTrue())
+		Expect(catErr.Params()).To(Equal(msgcat.Params{"name": "email", "detail": "secret@example.com"}))
+		Expect(catErr.RedactedParams()).To(Equal(msgcat.Params{"name": "email", "detail": msgcat.RedactedParamValue}))
+		Expect(catErr.RedactedParams("name")).To(Equal(msgcat.Params{"name": msgcat.RedactedParamValue, "detail": msgcat.RedactedParamValue}))
+
+		copied := catErr.Params()
+		copied["name"] = "changed"
+		Expect(catErr.Params()["name"]).To(Equal("email"))
+		Expect(messageCatalog.GetErrorWithCtx(ctx.Ctx, "greeting.hello", nil).(msgcat.Error).Params()).To(BeNil())
+	})
+
+	It("should encode messages and errors as stable JSON", func() {
+		ctx.SetValue("language", "es")
+		message := messageCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil)
+		encoded, err := json.Marshal(message)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(encoded)).To(MatchJSON(`{"key":"greeting.hello","code":"GREETING_HELLO","short":"Hola, breve descripción",
+			"long":"Hola, descripción muy larga. Solo puedes verme en la página de detalles.","lang":"es","requested_lang":"es"}`))
+
+		ctErr := messageCatalog.WrapErrorWithCtx(context.Background(), errors.New("db down"), "error.not_found", msgcat.Params{"id": "7"})
+		encoded, err = json.Marshal(ctErr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(encoded)).To(MatchJSON(`{"key":"error.not_found","code":"ERR_NOT_FOUND","short":"Not found",
+			"long":"The requested resource was not found","lang":"en","status":404,"params":{"id":"7"}}`))
+	})
+
+	It("should reconstruct errors from JSON for re-rendering", func() {
+		ctErr := messageCatalog.GetErrorWithCtx(ctx.Ctx, "greeting.template", msgcat.Params{"name": "Ana", "detail": "X"})
+		encoded, err := json.Marshal(ctErr)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded msgcat.DefaultError
+		Expect(json.Unmarshal(encoded, &decoded)).To(Succeed())
+		var received msgcat.Error = &decoded
+		Expect(received.Error()).To(Equal("Hello template Ana, this is nice X"))
+		Expect(received.ErrorCode()).To(Equal("2"))
+		Expect(received.Lang()).To(Equal("en"))
+		Expect(errors.Is(received, msgcat.KeyError("greeting.template"))).To(BeTrue())
+		reencoded, err := json.Marshal(decoded)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reencoded).To(MatchJSON(encoded))
+
+		ctx.SetValue("language", "es")
+		Expect(messageCatalog.Localize(ctx.Ctx, received).(msgcat.Error).Lang()).To(Equal("es"))
+
+		Expect(json.Unmarshal([]byte(`{"short":"no key"}`), &decoded)).NotTo(Succeed())
+	})
+
+	It("should aggregate localized validation errors per field", func() {
+		ctx.SetValue("language", "es")
+		validation := msgcat.NewValidationErrors(ctx.Ctx, messageCatalog,
+			msgcat.FieldViolation{Field: "email", Key: "validation.required", Params: msgcat.Params{"field": "email"}},
+			msgcat.FieldViolation{Field: "age", Key: "validation.min", Params: msgcat.Params{"field": "age", "min": 18}},
+		)
+		err := validation.ErrOrNil()
+		Expect(err).To(HaveOccurred())
+		Expect(validation.Lang).To(Equal("es"))
+		Expect(err.Error()).To(Equal("email: email es obligatorio; age: Error inesperado"))
+		Expect(validation.Fields()["email"].Code).To(Equal("REQUIRED"))
+
+		Expect(errors.Is(err, msgcat.KeyError("validation.required"))).To(BeTrue())
+		Expect(msgcat.HasKey(err, "validation.min")).To(BeTrue())
+		var fieldErr *msgcat.FieldError
+		Expect(errors.As(err, &fieldErr)).To(BeTrue())
+		Expect(fieldErr.Field).To(Equal("email"))
+
+		encoded, jsonErr := json.Marshal(err)
+		Expect(jsonErr).NotTo(HaveOccurred())
+		Expect(string(encoded)).To(MatchJSON(`{"errors":[
+			{"field":"email","key":"validation.required","code":"REQUIRED","message":"email es obligatorio","detail":"Indica un valor para email"},
+			{"field":"age","key":"validation.min","code":"msgcat.missing_message","message":"Error inesperado","detail":"Se recibió un error inesperado y no se encontró en el catálogo!"}]}`))
+
+		Expect(msgcat.NewValidationErrors(ctx.Ctx, messageCatalog).ErrOrNil()).To(BeNil())
+	})
+
 	It("should render pluralization and localized number/date tokens", func() {
 		date := time.Date(2026, time.January, 3, 10, 0, 0, 0, time.UTC)
 		params := msgcat.Params{"count": 3, "amount": 12345.5, "generatedAt": date}
@@ -332,6 +780,149 @@ var _ = Describe("Message Catalog", func() {
 		}, 500*time.Millisecond, 10*time.Millisecond).Should(BeNumerically(">", 0))
 	})
 
+	It("should load yml, json and toml catalog files", func() {
+		tmpDir, err := os.MkdirTemp("", "msgcat-formats-*")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+
+		files := map[string]string{
+			"en.json": `{"group": "api", "default": {"short": "Unexpected error"}, "set": {
+				"greeting.hello": {"short": "Hello {{name}}", "code": 200, "status": 200},
+				"items.count": {"short_forms": {"one": "{{count}} item", "other": "{{count}} items"}}}}`,
+			"es.toml":   "[default]\nshort = \"Error inesperado\"\n\n[set.\"greeting.hello\"]\nshort = \"Hola {{name}}\"\ncode = \"GREETING\"\nstatus = 200\n",
+			"pt.yml":    "default:\n  short: Erro inesperado\nset:\n  greeting.hello:\n    short: Olá {{name}}\n",
+			"notes.txt": "ignored",
+		}
+		for name, content := range files {
+			Expect(os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600)).To(Succeed())
+		}
+
+		customCatalog, err := msgcat.NewMessageCatalog(msgcat.Config{ResourcePath: tmpDir})
+		Expect(err).NotTo(HaveOccurred())
+		params := msgcat.Params{"name": "Ana", "count": 2}
+
+		msg := customCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", params)
+		Expect(msg.ShortText).To(Equal("Hello Ana"))
+		Expect(msg.Code).To(Equal("200"))
+		Expect(msg.Status).To(Equal(200))
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "items.count", params).ShortText).To(Equal("2 items"))
+		ctx.SetValue("language", "es")
+		msg = customCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", params)
+		Expect(msg.ShortText).To(Equal("Hola Ana"))
+		Expect(msg.Code).To(Equal("GREETING"))
+		ctx.SetValue("language", "pt")
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", params).ShortText).To(Equal("Olá Ana"))
+
+		Expect(os.WriteFile(filepath.Join(tmpDir, "en.yaml"), []byte("default:\n  short: Duplicate\n"), 0o600)).To(Succeed())
+		err = msgcat.Reload(customCatalog)
+		Expect(err).To(MatchError(ContainSubstring("duplicate message files for language en: en.json and en.yaml")))
+	})
+
+	It("should merge multiple files per language", func() {
+		tmpDir, err := os.MkdirTemp("", "msgcat-multi-*")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+		Expect(os.Mkdir(filepath.Join(tmpDir, "es"), 0o700)).To(Succeed())
+
+		files := map[string]string{
+			"en.yaml":         "default:\n  short: Unexpected error\nset:\n  greeting.hello:\n    short: Hello\n",
+			"en.billing.yaml": "set:\n  billing.due:\n    short: Payment due\n",
+			"es/core.yaml":    "default:\n  short: Error inesperado\nset:\n  greeting.hello:\n    short: Hola\n",
+			"es/billing.json": `{"set": {"billing.due": {"short": "Pago pendiente"}}}`,
+		}
+		for name, content := range files {
+			Expect(os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600)).To(Succeed())
+		}
+
+		customCatalog, err := msgcat.NewMessageCatalog(msgcat.Config{ResourcePath: tmpDir, SplitFiles: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil).ShortText).To(Equal("Hello"))
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "billing.due", nil).ShortText).To(Equal("Payment due"))
+		ctx.SetValue("language", "es")
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil).ShortText).To(Equal("Hola"))
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "billing.due", nil).ShortText).To(Equal("Pago pendiente"))
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "missing.key", nil).ShortText).To(Equal("Error inesperado"))
+
+		// msgcat merge output and directories that are not languages do not join a language.
+		Expect(os.Mkdir(filepath.Join(tmpDir, "drafts"), 0o700)).To(Succeed())
+		others := map[string]string{
+			"translate.es.yaml": "default:\n  short: Borrador\nset:\n  greeting.hello:\n    short: Hola\n",
+			"translate.fr.yaml": "default:\n  short: Brouillon\nset:\n  greeting.hello:\n    short: Salut\n",
+			"drafts/draft.yaml": "default:\n  short: Draft\n",
+		}
+		for name, content := range others {
+			Expect(os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600)).To(Succeed())
+		}
+		Expect(msgcat.Reload(customCatalog)).To(Succeed())
+		langs, err := msgcat.Languages(customCatalog)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(langs).To(ConsistOf("en", "es", "translate.es", "translate.fr"))
+
+		duplicate := "set:\n  billing.due:\n    short: Otra vez\n"
+		Expect(os.WriteFile(filepath.Join(tmpDir, "es", "extra.yaml"), []byte(duplicate), 0o600)).To(Succeed())
+		err = msgcat.Reload(customCatalog)
+		Expect(err).To(MatchError(ContainSubstring(`duplicate message key "billing.due" for language es in ` +
+			filepath.Join("es", "billing.json") + " and " + filepath.Join("es", "extra.yaml"))))
+	})
+
+	It("should load one file per language when SplitFiles is disabled", func() {
+		tmpDir, err := os.MkdirTemp("", "msgcat-single-*")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+		Expect(os.Mkdir(filepath.Join(tmpDir, "old"), 0o700)).To(Succeed())
+		Expect(os.Mkdir(filepath.Join(tmpDir, "es"), 0o700)).To(Succeed())
+
+		files := map[string]string{
+			"en.yaml":     "default:\n  short: Unexpected error\nset:\n  greeting.hello:\n    short: Hello\n",
+			"api.en.yaml": "default:\n  short: API error\nset:\n  greeting.hello:\n    short: Hello API\n",
+			"old/en.yaml": "default:\n  short: Old error\n",
+			"es/en.yaml":  "default:\n  short: Error inesperado\n",
+		}
+		for name, content := range files {
+			Expect(os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600)).To(Succeed())
+		}
+
+		customCatalog, err := msgcat.NewMessageCatalog(msgcat.Config{ResourcePath: tmpDir})
+		Expect(err).NotTo(HaveOccurred())
+		langs, err := msgcat.Languages(customCatalog)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(langs).To(ConsistOf("en", "api.en"))
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil).ShortText).To(Equal("Hello"))
+		ctx.SetValue("language", "api.en")
+		Expect(customCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil).ShortText).To(Equal("Hello API"))
+	})
+
+	It("should flatten nested keys when NestedKeys is enabled", func() {
+		tmpDir, err := os.MkdirTemp("", "msgcat-nested-*")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+
+		content := "default:\n  short: Unexpected error\nset:\n  greeting:\n    hello:\n      short: Hello\n      long: Hello there\n" +
+			"    formal.bye:\n      short: Goodbye\n  items:\n    count:\n      short_forms:\n        one: One item\n        other: Many items\n"
+		Expect(os.WriteFile(filepath.Join(tmpDir, "en.yaml"), []byte(content), 0o600)).To(Succeed())
+
+		nestedCatalog, err := msgcat.NewMessageCatalog(msgcat.Config{ResourcePath: tmpDir, NestedKeys: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nestedCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil).LongText).To(Equal("Hello there"))
+		Expect(nestedCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.formal.bye", nil).ShortText).To(Equal("Goodbye"))
+		Expect(nestedCatalog.GetMessageWithCtx(ctx.Ctx, "items.count", msgcat.Params{"count": 1}).ShortText).To(Equal("One item"))
+
+		flatCatalog, err := msgcat.NewMessageCatalog(msgcat.Config{ResourcePath: tmpDir})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(flatCatalog.GetMessageWithCtx(ctx.Ctx, "greeting.hello", nil).Missing).To(BeTrue())
+
+		var messages msgcat.Messages
+		_, err = msgcat.UnmarshalNestedMessages([]byte(content), &messages)
+		Expect(err).NotTo(HaveOccurred())
+		encoded, err := msgcat.MarshalNestedMessages(&messages)
+		Expect(err).NotTo(HaveOccurred())
+		var decoded msgcat.Messages
+		nested, err := msgcat.UnmarshalNestedMessages(encoded, &decoded)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nested).To(BeTrue())
+		Expect(decoded.Set).To(Equal(messages.Set))
+	})
+
 	It("should reload yaml changes and keep runtime loaded messages", func() {
 		tmpDir, err := os.MkdirTemp("", "msgcat-reload-*")
 		Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,105 @@
+package msgcat
+
+import (
+	"context"
+	"strings"
+)
+
+// FieldViolation is one invalid field in a request payload: the field name and the message key and
+// params describing the problem.
+type FieldViolation struct {
+	Field  string
+	Key    string
+	Params Params
+}
+
+// FieldError is a localized validation error for one field. Message is the short text and Detail the
+// long text. Params are not encoded to JSON since they often echo user input.
+type FieldError struct {
+	Field   string `json:"field"`
+	Key     string `json:"key"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	Detail  string `json:"detail,omitempty"`
+	Params  Params `json:"-"`
+}
+
+func (fe *FieldError) Error() string {
+	return fe.Field + ": " + fe.Message
+}
+
+// ErrorKey returns the message key, so HasKey finds field errors.
+func (fe *FieldError) ErrorKey() string {
+	return fe.Key
+}
+
+// Is reports whether target is a KeyError naming this field error's message key.
+func (fe *FieldError) Is(target error) bool {
+	key, ok := target.(KeyError)
+	return ok && fe.Key == string(key)
+}
+
+// ValidationErrors aggregates localized field errors. It encodes to JSON as
+// {"errors":[{"field":"email","key":"...","message":"..."}]}.
+type ValidationErrors struct {
+	Errors []*FieldError `json:"errors"`
+	Lang   string        `json:"-"` // Resolved language of the messages (e.g. for Content-Language).
+}
+
+// NewValidationErrors renders every violation with GetMessageWithCtx in the context language.
+// Use ErrOrNil to return it as an error only when there are violations.
+func NewValidationErrors(ctx context.Context, catalog MessageCatalog, violations ...FieldViolation) *ValidationErrors {
+	ve := &ValidationErrors{Errors: make([]*FieldError, 0, len(violations))}
+	for _, violation := range violations {
+		message := catalog.GetMessageWithCtx(ctx, violation.Key, violation.Params)
+		if ve.Lang == "" {
+			ve.Lang = message.Lang
+		}
+		ve.Errors = append(ve.Errors, &FieldError{
+			Field:   violation.Field,
+			Key:     violation.Key,
+			Code:    message.Code,
+			Message: message.ShortText,
+			Detail:  message.LongText,
+			Params:  copyParams(violation.Params),
+		})
+	}
+	return ve
+}
+
+// ErrOrNil returns ve as an error, or nil when it has no field errors.
+func (ve *ValidationErrors) ErrOrNil() error {
+	if ve == nil || len(ve.Errors) == 0 {
+		return nil
+	}
+	return ve
+}
+
+// Error joins the field errors, e.g. "email: Required; age: Too young".
+func (ve *ValidationErrors) Error() string {
+	parts := make([]string, len(ve.Errors))
+	for i, fe := range ve.Errors {
+		parts[i] = fe.Error()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Unwrap returns the field errors, so errors.Is, errors.As and HasKey see each of them.
+func (ve *ValidationErrors) Unwrap() []error {
+	out := make([]error, len(ve.Errors))
+	for i, fe := range ve.Errors {
+		out[i] = fe
+	}
+	return out
+}
+
+// Fields returns the field errors keyed by field name (first error per field).
+func (ve *ValidationErrors) Fields() map[string]*FieldError {
+	out := make(map[string]*FieldError, len(ve.Errors))
+	for _, fe := range ve.Errors {
+		if _, exists := out[fe.Field]; !exists {
+			out[fe.Field] = fe
+		}
+	}
+	return out
+}